@@ -0,0 +1,293 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrNoAssetType is returned when no asset type can be detected for
+// an identifier.
+var ErrNoAssetType = errors.New("no asset type detected")
+
+// byteOrderMark is the UTF-8 encoded byte order mark.
+const byteOrderMark = "\ufeff"
+
+// ImportFormat is the format of an asset list read by [ImportReader].
+type ImportFormat string
+
+// Supported import formats.
+const (
+	// TextFormat is a newline-delimited list of identifiers. Empty
+	// lines and lines starting with "#" are ignored.
+	TextFormat ImportFormat = "text"
+	// CSVFormat is a CSV file with a header row.
+	CSVFormat ImportFormat = "csv"
+	// JSONLFormat is a JSON Lines file with one JSON object per line.
+	// Empty lines are ignored.
+	JSONLFormat ImportFormat = "jsonl"
+)
+
+// Default column names used by [ImportReader].
+const (
+	DefaultIdentifierColumn = "identifier"
+	DefaultTypeColumn       = "type"
+)
+
+// ImportOptions configures an [ImportReader].
+type ImportOptions struct {
+	// Format is the format of the asset list. It defaults to
+	// TextFormat.
+	Format ImportFormat
+
+	// IdentifierColumn is the CSV column or JSON field containing the
+	// identifier. It defaults to DefaultIdentifierColumn.
+	IdentifierColumn string
+
+	// TypeColumn is the CSV column or JSON field containing the
+	// declared asset type. It defaults to DefaultTypeColumn. If a
+	// record has no declared type, its asset types are detected.
+	TypeColumn string
+
+	// Detect configures how hostnames, domain names and web
+	// addresses are validated and detected.
+	Detect DetectOptions
+}
+
+// ImportRecord is an asset read by an [ImportReader].
+type ImportRecord struct {
+	// Line is the line number of the record in the input, starting
+	// at 1.
+	Line int
+	// Identifier is the asset identifier.
+	Identifier string
+	// DeclaredType is the asset type declared in the input, if any.
+	DeclaredType AssetType
+	// Types contains the asset types of the identifier. If the record
+	// has a declared type, it only contains that type.
+	Types []AssetType
+	// Err is not nil if the record is not valid: its declared type is
+	// unknown, the identifier is not valid for its declared type or
+	// no asset type could be detected.
+	Err error
+}
+
+// ImportReader reads asset lists in text, CSV and JSON Lines format.
+// It validates the identifiers with a declared type using
+// [ValidateWithOptions] and detects the asset types of the rest using
+// [DetectAssetTypesWithOptions].
+type ImportReader struct {
+	opts ImportOptions
+
+	scanner *bufio.Scanner
+	line    int
+
+	csv       *csv.Reader
+	idColumn  int
+	typColumn int
+}
+
+// NewImportReader returns an [ImportReader] that reads from r. In the
+// case of CSV, the header is read to locate the configured columns
+// and an error is returned if the identifier column is missing.
+func NewImportReader(r io.Reader, opts ImportOptions) (*ImportReader, error) {
+	if opts.Format == "" {
+		opts.Format = TextFormat
+	}
+	if opts.IdentifierColumn == "" {
+		opts.IdentifierColumn = DefaultIdentifierColumn
+	}
+	if opts.TypeColumn == "" {
+		opts.TypeColumn = DefaultTypeColumn
+	}
+
+	ir := &ImportReader{opts: opts}
+	switch opts.Format {
+	case TextFormat, JSONLFormat:
+		ir.scanner = bufio.NewScanner(r)
+		ir.scanner.Buffer(nil, 1024*1024)
+	case CSVFormat:
+		ir.csv = csv.NewReader(r)
+		ir.csv.FieldsPerRecord = -1
+		ir.csv.TrimLeadingSpace = true
+
+		header, err := ir.csv.Read()
+		if err != nil {
+			return nil, fmt.Errorf("read CSV header: %w", err)
+		}
+		ir.idColumn, ir.typColumn = -1, -1
+		// Spreadsheet applications usually prepend a byte order
+		// mark to the files they export.
+		if len(header) > 0 {
+			header[0] = strings.TrimPrefix(header[0], byteOrderMark)
+		}
+		for i, name := range header {
+			switch strings.TrimSpace(name) {
+			case opts.IdentifierColumn:
+				ir.idColumn = i
+			case opts.TypeColumn:
+				ir.typColumn = i
+			}
+		}
+		if ir.idColumn < 0 {
+			return nil, fmt.Errorf("missing CSV column: %v", opts.IdentifierColumn)
+		}
+	default:
+		return nil, fmt.Errorf("unknown import format: %v", opts.Format)
+	}
+	return ir, nil
+}
+
+// Read returns the next record. Invalid records are returned with a
+// non-nil [ImportRecord.Err]. It returns [io.EOF] when there are no
+// more records and a different error if the input cannot be read.
+func (ir *ImportReader) Read() (ImportRecord, error) {
+	var (
+		rec ImportRecord
+		typ string
+		err error
+	)
+	switch ir.opts.Format {
+	case CSVFormat:
+		rec, typ, err = ir.readCSV()
+	case JSONLFormat:
+		rec, typ, err = ir.readJSONL()
+	default:
+		rec, err = ir.readText()
+	}
+	if err != nil || rec.Err != nil {
+		return rec, err
+	}
+
+	ir.process(&rec, typ)
+	return rec, nil
+}
+
+// process validates or detects the asset types of the record.
+func (ir *ImportReader) process(rec *ImportRecord, typ string) {
+	if rec.Identifier == "" {
+		rec.Err = errors.New("missing identifier")
+		return
+	}
+
+	if typ != "" {
+		t, err := Parse(typ)
+		if err != nil {
+			rec.Err = err
+			return
+		}
+		rec.DeclaredType = t
+		if err := ValidateWithOptions(rec.Identifier, t, ir.opts.Detect); err != nil {
+			rec.Err = err
+			return
+		}
+		rec.Types = []AssetType{t}
+		return
+	}
+
+	types, err := DetectAssetTypesWithOptions(rec.Identifier, ir.opts.Detect)
+	if err != nil {
+		rec.Err = err
+		return
+	}
+	if len(types) == 0 {
+		rec.Err = fmt.Errorf("%w: %v", ErrNoAssetType, rec.Identifier)
+		return
+	}
+	rec.Types = types
+}
+
+// nextLine returns the next non-empty line.
+func (ir *ImportReader) nextLine() (string, error) {
+	for ir.scanner.Scan() {
+		ir.line++
+		line := ir.scanner.Text()
+		if ir.line == 1 {
+			line = strings.TrimPrefix(line, byteOrderMark)
+		}
+		line = strings.TrimSpace(line)
+		if line != "" {
+			return line, nil
+		}
+	}
+	if err := ir.scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", io.EOF
+}
+
+func (ir *ImportReader) readText() (ImportRecord, error) {
+	for {
+		line, err := ir.nextLine()
+		if err != nil {
+			return ImportRecord{}, err
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		return ImportRecord{Line: ir.line, Identifier: line}, nil
+	}
+}
+
+func (ir *ImportReader) readJSONL() (ImportRecord, string, error) {
+	line, err := ir.nextLine()
+	if err != nil {
+		return ImportRecord{}, "", err
+	}
+
+	rec := ImportRecord{Line: ir.line}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(line), &fields); err != nil {
+		rec.Err = fmt.Errorf("invalid JSON: %w", err)
+		return rec, "", nil
+	}
+
+	id, ok := fields[ir.opts.IdentifierColumn].(string)
+	if !ok {
+		rec.Err = fmt.Errorf("missing string field: %v", ir.opts.IdentifierColumn)
+		return rec, "", nil
+	}
+	rec.Identifier = strings.TrimSpace(id)
+
+	var typ string
+	if v, ok := fields[ir.opts.TypeColumn]; ok && v != nil {
+		if typ, ok = v.(string); !ok {
+			rec.Err = fmt.Errorf("invalid field %v: not a string", ir.opts.TypeColumn)
+			return rec, "", nil
+		}
+	}
+	return rec, strings.TrimSpace(typ), nil
+}
+
+func (ir *ImportReader) readCSV() (ImportRecord, string, error) {
+	fields, err := ir.csv.Read()
+	if err != nil {
+		var perr *csv.ParseError
+		if errors.As(err, &perr) {
+			return ImportRecord{Line: perr.Line, Err: err}, "", nil
+		}
+		return ImportRecord{}, "", err
+	}
+
+	line, _ := ir.csv.FieldPos(0)
+	rec := ImportRecord{Line: line}
+	if ir.idColumn >= len(fields) {
+		rec.Err = fmt.Errorf("missing CSV column: %v", ir.opts.IdentifierColumn)
+		return rec, "", nil
+	}
+	rec.Identifier = strings.TrimSpace(fields[ir.idColumn])
+
+	var typ string
+	if ir.typColumn >= 0 && ir.typColumn < len(fields) {
+		typ = strings.TrimSpace(fields[ir.typColumn])
+	}
+	return rec, typ, nil
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// importResult is a simplified [ImportRecord] used to compare test
+// results.
+type importResult struct {
+	Line         int
+	Identifier   string
+	DeclaredType AssetType
+	Types        []AssetType
+	Err          bool
+}
+
+func readAllRecords(t *testing.T, ir *ImportReader) []importResult {
+	t.Helper()
+
+	var results []importResult
+	for {
+		rec, err := ir.Read()
+		if errors.Is(err, io.EOF) {
+			return results
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		results = append(results, importResult{
+			Line:         rec.Line,
+			Identifier:   rec.Identifier,
+			DeclaredType: rec.DeclaredType,
+			Types:        rec.Types,
+			Err:          rec.Err != nil,
+		})
+	}
+}
+
+func TestImportReader(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  ImportOptions
+		want  []importResult
+	}{
+		{
+			name: "text",
+			input: "# Assets\n" +
+				"192.0.2.1\n" +
+				"\n" +
+				"  192.0.2.0/24  \n" +
+				"finntech/docker-elasticsearch-kubernetes\n",
+			opts: ImportOptions{Format: TextFormat},
+			want: []importResult{
+				{Line: 2, Identifier: "192.0.2.1", Types: []AssetType{IP}},
+				{Line: 4, Identifier: "192.0.2.0/24", Types: []AssetType{IPRange}},
+				{Line: 5, Identifier: "finntech/docker-elasticsearch-kubernetes", Err: true},
+			},
+		},
+		{
+			name: "CSV with custom columns",
+			input: "name,asset,kind\n" +
+				"ip,192.0.2.1,IP\n" +
+				"range,192.0.2.1/32,IPRange\n" +
+				"image,ghcr.io/puppeteer/puppeteer,\n" +
+				"bad,192.0.2.1,Hostnme\n",
+			opts: ImportOptions{Format: CSVFormat, IdentifierColumn: "asset", TypeColumn: "kind"},
+			want: []importResult{
+				{Line: 2, Identifier: "192.0.2.1", DeclaredType: IP, Types: []AssetType{IP}},
+				{Line: 3, Identifier: "192.0.2.1/32", DeclaredType: IPRange, Err: true},
+				{Line: 4, Identifier: "ghcr.io/puppeteer/puppeteer", Types: []AssetType{DockerImage}},
+				{Line: 5, Identifier: "192.0.2.1", Err: true},
+			},
+		},
+		{
+			name: "CSV without type column",
+			input: "identifier\n" +
+				"arn:aws:iam::123456789012:root\n",
+			opts: ImportOptions{Format: CSVFormat},
+			want: []importResult{
+				{Line: 2, Identifier: "arn:aws:iam::123456789012:root", Types: []AssetType{AWSAccount}},
+			},
+		},
+		{
+			name: "CSV with byte order mark",
+			input: "\ufeffidentifier,type\n" +
+				"192.0.2.1,IP\n",
+			opts: ImportOptions{Format: CSVFormat},
+			want: []importResult{
+				{Line: 2, Identifier: "192.0.2.1", DeclaredType: IP, Types: []AssetType{IP}},
+			},
+		},
+		{
+			name:  "text with byte order mark",
+			input: "\ufeff192.0.2.1\n",
+			opts:  ImportOptions{Format: TextFormat},
+			want: []importResult{
+				{Line: 1, Identifier: "192.0.2.1", Types: []AssetType{IP}},
+			},
+		},
+		{
+			name: "offline",
+			input: "identifier,type\n" +
+				"www.adevinta.com,\n" +
+				"adevinta.com,DomainName\n" +
+				"www.adevinta.com,DomainName\n",
+			opts: ImportOptions{Format: CSVFormat, Detect: DetectOptions{Offline: true}},
+			want: []importResult{
+				{Line: 2, Identifier: "www.adevinta.com", Types: []AssetType{Hostname}},
+				{Line: 3, Identifier: "adevinta.com", DeclaredType: DomainName, Types: []AssetType{DomainName}},
+				{Line: 4, Identifier: "www.adevinta.com", DeclaredType: DomainName, Err: true},
+			},
+		},
+		{
+			name: "JSONL",
+			input: `{"identifier": "192.0.2.1", "type": "IP"}` + "\n" +
+				"\n" +
+				`{"identifier": "*.example.com"}` + "\n" +
+				`{"identifier": 31337}` + "\n" +
+				`not json` + "\n",
+			opts: ImportOptions{Format: JSONLFormat},
+			want: []importResult{
+				{Line: 1, Identifier: "192.0.2.1", DeclaredType: IP, Types: []AssetType{IP}},
+				{Line: 3, Identifier: "*.example.com", Types: []AssetType{DomainPattern}},
+				{Line: 4, Err: true},
+				{Line: 5, Err: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ir, err := NewImportReader(strings.NewReader(tt.input), tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := readAllRecords(t, ir)
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("records mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestNewImportReader_errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  ImportOptions
+	}{
+		{
+			name:  "missing CSV column",
+			input: "asset,type\n192.0.2.1,IP\n",
+			opts:  ImportOptions{Format: CSVFormat},
+		},
+		{
+			name:  "empty CSV",
+			input: "",
+			opts:  ImportOptions{Format: CSVFormat},
+		},
+		{
+			name:  "unknown format",
+			input: "192.0.2.1\n",
+			opts:  ImportOptions{Format: "xml"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewImportReader(strings.NewReader(tt.input), tt.opts); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"errors"
+	"sort"
+)
+
+// defaultConfidence is the confidence of the custom asset types
+// registered with [Register].
+const defaultConfidence = 0.5
+
+// builtinConfidence contains the confidence of the built-in asset
+// types. Types whose syntax is less likely to be shared with other
+// types have a higher confidence.
+var builtinConfidence = map[AssetType]float64{
+	AWSAccount:     1,
+	IP:             1,
+	IPRange:        1,
+	DomainPattern:  1,
+	ServiceURL:     1,
+	NetworkService: 1,
+	GitRepository:  0.9,
+	WebAddress:     0.8,
+	DockerImage:    0.7,
+	Hostname:       0.7,
+	DomainName:     0.6,
+}
+
+// Candidate is an asset type an identifier may belong to.
+type Candidate struct {
+	// Type is the asset type.
+	Type AssetType
+	// Confidence is a score between 0 and 1 that indicates how likely
+	// the identifier is an asset of this type.
+	Confidence float64
+}
+
+// Detection is the result of [DetectAssetTypeCandidates].
+type Detection struct {
+	// Candidates contains all the asset types the identifier is
+	// valid for, sorted by descending confidence.
+	Candidates []Candidate
+	// Primary is the recommended asset type. It is empty if there
+	// are no candidates.
+	Primary AssetType
+}
+
+// Ambiguous reports whether the identifier is valid for more than one
+// asset type.
+func (d Detection) Ambiguous() bool {
+	return len(d.Candidates) > 1
+}
+
+// DetectAssetTypeCandidates detects the asset types from an
+// identifier. Unlike [DetectAssetTypes], which returns on the first
+// match, it evaluates the checkers of all the known asset types and
+// returns every matching type with a confidence score, so ambiguous
+// identifiers can be disambiguated by the user. For instance,
+// "https://github.com/adevinta/vulcan-types.git" is both a
+// GitRepository and a WebAddress.
+//
+// The checkers of the asset types that require network access are
+// only evaluated if no asset type with full confidence, like IP or
+// AWSAccount, matches without network access. The confidence of a
+// WebAddress whose hostname cannot be resolved is lowered, as
+// [DetectAssetTypes] would not report it. Candidates with the same
+// confidence are sorted in registration order.
+func DetectAssetTypeCandidates(identifier string) (Detection, error) {
+	return DetectAssetTypeCandidatesWithOptions(identifier, DetectOptions{})
+}
+
+// DetectAssetTypeCandidatesWithOptions is like
+// [DetectAssetTypeCandidates] but allows to configure how hostnames,
+// domain names and web addresses are checked. See [DetectOptions].
+func DetectAssetTypeCandidatesWithOptions(identifier string, opts DetectOptions) (Detection, error) {
+	specs := AllAssetTypes()
+	matched := make([]bool, len(specs))
+
+	certain := false
+	for i, spec := range specs {
+		if spec.RequiresNetwork {
+			continue
+		}
+		if err := spec.Validate(identifier); err == nil {
+			matched[i] = true
+			certain = certain || candidateConfidence(spec) == 1
+		}
+	}
+
+	if !certain {
+		for i, spec := range specs {
+			if !spec.RequiresNetwork {
+				continue
+			}
+			validate := specValidator(spec, opts)
+			if spec.builtin && spec.Name == WebAddress {
+				// Unresolved web addresses are kept with a lower
+				// confidence.
+				acceptOpts := opts
+				acceptOpts.AcceptUnresolvedWebAddresses = true
+				validate = webAddressValidator(acceptOpts)
+			}
+			if err := validate(identifier); err != nil {
+				if errors.Is(err, ErrDNSQuery) {
+					return Detection{}, err
+				}
+				continue
+			}
+			matched[i] = true
+		}
+	}
+
+	var candidates []Candidate
+	for i, spec := range specs {
+		if !matched[i] {
+			continue
+		}
+		confidence := candidateConfidence(spec)
+		if spec.builtin && spec.Name == WebAddress {
+			if status, err := WebAddressHostStatus(identifier, opts); err == nil && status == HostUnresolved {
+				confidence /= 2
+			}
+		}
+		candidates = append(candidates, Candidate{Type: spec.Name, Confidence: confidence})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Confidence > candidates[j].Confidence
+	})
+
+	d := Detection{Candidates: candidates}
+	if len(candidates) > 0 {
+		d.Primary = candidates[0].Type
+	}
+	return d, nil
+}
+
+// candidateConfidence returns the confidence of a candidate of the
+// provided asset type.
+func candidateConfidence(spec AssetTypeSpec) float64 {
+	if spec.builtin {
+		return builtinConfidence[spec.Name]
+	}
+	return defaultConfidence
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDetectAssetTypeCandidates(t *testing.T) {
+	startTestDNSServer(t, testZone{
+		"localhost. 3600 IN SOA ns.localhost. admin.localhost. 1 7200 3600 1209600 3600",
+	})
+
+	// unreachable is the address of a DNS server that does not
+	// answer.
+	const unreachable = "127.0.0.1:1"
+
+	tests := []struct {
+		name          string
+		identifier    string
+		opts          DetectOptions
+		want          Detection
+		wantAmbiguous bool
+		wantErr       bool
+	}{
+		{
+			name:       "IP",
+			identifier: "192.0.2.1",
+			want: Detection{
+				Candidates: []Candidate{{Type: IP, Confidence: 1}},
+				Primary:    IP,
+			},
+		},
+		{
+			name:       "Git repository and web address",
+			identifier: "http://localhost/adevinta/vulcan-types.git",
+			want: Detection{
+				Candidates: []Candidate{
+					{Type: GitRepository, Confidence: 0.9},
+					{Type: WebAddress, Confidence: 0.8},
+				},
+				Primary: GitRepository,
+			},
+			wantAmbiguous: true,
+		},
+		{
+			name:       "Git repository and unresolvable web address",
+			identifier: "http://not.a.host.name/vulcan-types.git",
+			want: Detection{
+				Candidates: []Candidate{
+					{Type: GitRepository, Confidence: 0.9},
+					{Type: WebAddress, Confidence: 0.4},
+				},
+				Primary: GitRepository,
+			},
+			wantAmbiguous: true,
+		},
+		{
+			name:       "Web address with IP host",
+			identifier: "https://192.0.2.1:8443/",
+			want: Detection{
+				Candidates: []Candidate{{Type: WebAddress, Confidence: 0.8}},
+				Primary:    WebAddress,
+			},
+		},
+		{
+			name:       "Hostname and domain name",
+			identifier: "localhost",
+			want: Detection{
+				Candidates: []Candidate{
+					{Type: Hostname, Confidence: 0.7},
+					{Type: DomainName, Confidence: 0.6},
+				},
+				Primary: Hostname,
+			},
+			wantAmbiguous: true,
+		},
+		{
+			name:       "Docker image",
+			identifier: "localhost:5000/foo",
+			want: Detection{
+				Candidates: []Candidate{{Type: DockerImage, Confidence: 0.7}},
+				Primary:    DockerImage,
+			},
+		},
+		{
+			name:       "Offline hostname",
+			identifier: "www.example.com",
+			opts:       DetectOptions{Offline: true},
+			want: Detection{
+				Candidates: []Candidate{{Type: Hostname, Confidence: 0.7}},
+				Primary:    Hostname,
+			},
+		},
+		{
+			name:       "Offline hostname and domain name",
+			identifier: "example.com",
+			opts:       DetectOptions{Offline: true},
+			want: Detection{
+				Candidates: []Candidate{
+					{Type: Hostname, Confidence: 0.7},
+					{Type: DomainName, Confidence: 0.6},
+				},
+				Primary: Hostname,
+			},
+			wantAmbiguous: true,
+		},
+		{
+			name:       "IP with unreachable DNS server",
+			identifier: "192.0.2.1",
+			opts:       DetectOptions{Resolver: unreachable},
+			want: Detection{
+				Candidates: []Candidate{{Type: IP, Confidence: 1}},
+				Primary:    IP,
+			},
+		},
+		{
+			name:       "AWS account with unreachable DNS server",
+			identifier: "arn:aws:iam::123456789012:root",
+			opts:       DetectOptions{Resolver: unreachable},
+			want: Detection{
+				Candidates: []Candidate{{Type: AWSAccount, Confidence: 1}},
+				Primary:    AWSAccount,
+			},
+		},
+		{
+			name:       "Hostname with unreachable DNS server",
+			identifier: "www.example.com",
+			opts:       DetectOptions{Resolver: unreachable},
+			want:       Detection{},
+			wantErr:    true,
+		},
+		{
+			name:       "No candidates",
+			identifier: "finntech/docker-elasticsearch-kubernetes",
+			want:       Detection{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectAssetTypeCandidatesWithOptions(tt.identifier, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("detection mismatch (-want +got):\n%v", diff)
+			}
+			if got.Ambiguous() != tt.wantAmbiguous {
+				t.Errorf("got ambiguous %v, want %v", got.Ambiguous(), tt.wantAmbiguous)
+			}
+		})
+	}
+}
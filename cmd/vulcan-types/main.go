@@ -0,0 +1,220 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+// Vulcan-types detects the asset types of identifiers.
+//
+// Usage:
+//
+//	vulcan-types [flags] [identifier ...]
+//
+// If no identifiers are provided as arguments, they are read from the
+// standard input, one per line. Empty lines and lines starting with
+// "#" are ignored.
+//
+// The flags are:
+//
+//	-offline
+//		Do not perform network access. Hostnames are validated
+//		syntactically and only registrable domains are considered
+//		domain names.
+//	-type type
+//		Validate the identifiers against the provided asset type
+//		instead of detecting their asset types.
+//	-normalize
+//		Print the normalized form of the identifiers.
+//	-resolver addr
+//		Address of the DNS server used to resolve names, like
+//		"192.0.2.53" or "192.0.2.53:5353".
+//	-accept-unresolved
+//		Detect web addresses whose hostnames cannot be resolved.
+//	-format text|json
+//		Output format. The json format prints one JSON object per
+//		line.
+//
+// The exit status is 1 if any identifier is not valid or no asset
+// type can be detected for it, and 2 if the command is misused.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	types "github.com/adevinta/vulcan-types"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// result is the outcome of processing an identifier.
+type result struct {
+	Identifier string            `json:"identifier"`
+	Types      []types.AssetType `json:"types"`
+	Normalized string            `json:"normalized,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// config contains the command-line options.
+type config struct {
+	opts      types.DetectOptions
+	assetType types.AssetType
+	normalize bool
+	format    string
+}
+
+// run runs the command with the provided arguments and returns its
+// exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("vulcan-types", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: vulcan-types [flags] [identifier ...]\n")
+		fs.PrintDefaults()
+	}
+
+	var (
+		cfg     config
+		typeArg string
+	)
+	fs.BoolVar(&cfg.opts.Offline, "offline", false, "do not perform network access")
+	fs.StringVar(&typeArg, "type", "", "validate the identifiers against the provided asset `type`")
+	fs.BoolVar(&cfg.normalize, "normalize", false, "print the normalized form of the identifiers")
+	fs.StringVar(&cfg.opts.Resolver, "resolver", "", "`address` of the DNS server used to resolve names")
+	fs.BoolVar(&cfg.opts.AcceptUnresolvedWebAddresses, "accept-unresolved", false, "detect web addresses whose hostnames cannot be resolved")
+	fs.StringVar(&cfg.format, "format", "text", "output `format`: text or json")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if cfg.format != "text" && cfg.format != "json" {
+		fmt.Fprintf(stderr, "error: unknown format: %v\n", cfg.format)
+		return 2
+	}
+	if typeArg != "" {
+		t, err := types.Parse(typeArg)
+		if err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return 2
+		}
+		cfg.assetType = t
+	}
+
+	identifiers := fs.Args()
+	if len(identifiers) == 0 {
+		var err error
+		if identifiers, err = readIdentifiers(stdin); err != nil {
+			fmt.Fprintf(stderr, "error: read identifiers: %v\n", err)
+			return 2
+		}
+	}
+
+	status := 0
+	for _, identifier := range identifiers {
+		res := process(identifier, cfg)
+		if res.Error != "" {
+			status = 1
+		}
+		if err := printResult(stdout, res, cfg.format); err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return 2
+		}
+	}
+	return status
+}
+
+// readIdentifiers reads the identifiers from r, one per line.
+func readIdentifiers(r io.Reader) ([]string, error) {
+	var identifiers []string
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		identifiers = append(identifiers, line)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return identifiers, nil
+}
+
+// process validates the identifier against the configured asset type
+// or detects its asset types if no type is configured.
+func process(identifier string, cfg config) result {
+	res := result{Identifier: identifier}
+
+	if cfg.assetType != "" {
+		if err := types.ValidateWithOptions(identifier, cfg.assetType, cfg.opts); err != nil {
+			res.Error = err.Error()
+			return res
+		}
+		res.Types = []types.AssetType{cfg.assetType}
+	} else {
+		assetTypes, err := types.DetectAssetTypesWithOptions(identifier, cfg.opts)
+		if err != nil {
+			res.Error = err.Error()
+			return res
+		}
+		if len(assetTypes) == 0 {
+			res.Error = types.ErrNoAssetType.Error()
+			return res
+		}
+		res.Types = assetTypes
+	}
+
+	if cfg.normalize {
+		normalized, err := types.Normalize(identifier, ownerType(res.Types))
+		if err != nil {
+			res.Error = err.Error()
+			return res
+		}
+		res.Normalized = normalized
+	}
+	return res
+}
+
+// ownerType returns the asset type that the identifier represents as
+// a whole, which determines how it is normalized. A URL like
+// https://192.0.2.1/ is detected as both IP and WebAddress, but it
+// must be normalized as a web address.
+func ownerType(assetTypes []types.AssetType) types.AssetType {
+	for _, t := range assetTypes {
+		if t == types.WebAddress {
+			return t
+		}
+	}
+	return assetTypes[0]
+}
+
+// printResult writes res to w in the provided format.
+func printResult(w io.Writer, res result, format string) error {
+	if format == "json" {
+		return json.NewEncoder(w).Encode(res)
+	}
+
+	var out string
+	switch {
+	case res.Error != "":
+		out = "error: " + res.Error
+	default:
+		ts := make([]string, len(res.Types))
+		for i, t := range res.Types {
+			ts[i] = string(t)
+		}
+		out = strings.Join(ts, ",")
+		if res.Normalized != "" {
+			out += "\t" + res.Normalized
+		}
+	}
+	if _, err := fmt.Fprintf(w, "%v\t%v\n", res.Identifier, out); err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+	return nil
+}
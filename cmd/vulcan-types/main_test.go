@@ -0,0 +1,92 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantStdout string
+		wantStatus int
+	}{
+		{
+			name:       "args",
+			args:       []string{"-offline", "192.0.2.1", "192.0.2.0/24"},
+			wantStdout: "192.0.2.1\tIP\n192.0.2.0/24\tIPRange\n",
+			wantStatus: 0,
+		},
+		{
+			name:       "stdin",
+			args:       []string{"-offline"},
+			stdin:      "# comment\narn:aws:iam::123456789012:root\n\nadevinta.com\n",
+			wantStdout: "arn:aws:iam::123456789012:root\tAWSAccount\nadevinta.com\tHostname,DomainName\n",
+			wantStatus: 0,
+		},
+		{
+			name:       "no asset type",
+			args:       []string{"-offline", "not an asset"},
+			wantStdout: "not an asset\terror: no asset type detected\n",
+			wantStatus: 1,
+		},
+		{
+			name:       "declared type",
+			args:       []string{"-offline", "-type", "IP", "192.0.2.1", "192.0.2.0/24"},
+			wantStdout: "192.0.2.1\tIP\n192.0.2.0/24\terror: invalid IP \"192.0.2.0/24\": not an IP\n",
+			wantStatus: 1,
+		},
+		{
+			name:       "normalize",
+			args:       []string{"-offline", "-normalize", "-type", "Hostname", "WWW.Adevinta.COM."},
+			wantStdout: "WWW.Adevinta.COM.\tHostname\twww.adevinta.com\n",
+			wantStatus: 0,
+		},
+		{
+			name: "normalize web address",
+			args: []string{"-offline", "-normalize", "https://www.example.com/a/../b", "https://10.0.0.1:8443/"},
+			wantStdout: "https://www.example.com/a/../b\tHostname,WebAddress\thttps://www.example.com/b\n" +
+				"https://10.0.0.1:8443/\tIP,WebAddress\thttps://10.0.0.1:8443/\n",
+			wantStatus: 0,
+		},
+		{
+			name:       "json",
+			args:       []string{"-offline", "-format", "json", "-normalize", "192.0.2.1/32", "foo bar"},
+			wantStdout: `{"identifier":"192.0.2.1/32","types":["IP"],"normalized":"192.0.2.1"}` + "\n" + `{"identifier":"foo bar","types":null,"error":"no asset type detected"}` + "\n",
+			wantStatus: 1,
+		},
+		{
+			name:       "unknown type",
+			args:       []string{"-type", "Hostnme", "192.0.2.1"},
+			wantStatus: 2,
+		},
+		{
+			name:       "unknown format",
+			args:       []string{"-format", "xml", "192.0.2.1"},
+			wantStatus: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			status := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if status != tt.wantStatus {
+				t.Errorf("unexpected status: got %v, want %v (stderr: %q)", status, tt.wantStatus, stderr.String())
+			}
+			if diff := cmp.Diff(tt.wantStdout, stdout.String()); diff != "" {
+				t.Errorf("stdout mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
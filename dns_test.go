@@ -0,0 +1,133 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/miekg/dns"
+)
+
+// testZone is a set of DNS records served by a test DNS server, in
+// zone file format.
+type testZone []string
+
+// startTestDNSServer starts a DNS server that answers queries with the
+// records of the provided zone and configures the package to use it
+// for the duration of the test. It follows CNAME records like a
+// recursive resolver. It returns the address of the server.
+func startTestDNSServer(t *testing.T, zone testZone) string {
+	t.Helper()
+	return startTestDNSHandler(t, zoneHandler(t, zone, true))
+}
+
+// zoneHandler returns a DNS handler that answers queries with the
+// records of the provided zone. If recursive is true, CNAME records
+// are followed like a recursive resolver does. Otherwise, only the
+// records owned by the queried name are returned, like an
+// authoritative server does for CNAME targets outside of its zone.
+func zoneHandler(t *testing.T, zone testZone, recursive bool) dns.Handler {
+	t.Helper()
+
+	var rrs []dns.RR
+	for _, s := range zone {
+		rr, err := dns.NewRR(s)
+		if err != nil {
+			t.Fatalf("invalid record %q: %v", s, err)
+		}
+		rrs = append(rrs, rr)
+	}
+
+	return dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+		m := &dns.Msg{}
+		m.SetReply(req)
+		q := req.Question[0]
+		name := q.Name
+		exists := false
+		// Follow the CNAME records like a recursive resolver, up to
+		// a maximum number of steps to avoid loops.
+		for i := 0; i < 8; i++ {
+			var cname string
+			for _, rr := range matchingRecords(rrs, name) {
+				h := rr.Header()
+				exists = true
+				if h.Rrtype == q.Qtype || q.Qtype == dns.TypeANY {
+					m.Answer = append(m.Answer, rr)
+				} else if c, ok := rr.(*dns.CNAME); ok {
+					m.Answer = append(m.Answer, rr)
+					cname = c.Target
+				}
+			}
+			if cname == "" || !recursive {
+				break
+			}
+			name = cname
+			exists = false
+		}
+		if !exists {
+			m.Rcode = dns.RcodeNameError
+		}
+		w.WriteMsg(m)
+	})
+}
+
+// startTestDNSHandler starts a DNS server that answers queries with
+// the provided handler and configures the package to use it for the
+// duration of the test. It returns the address of the server.
+func startTestDNSHandler(t *testing.T, handler dns.Handler) string {
+	t.Helper()
+
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	started := make(chan struct{})
+	srv := &dns.Server{PacketConn: pc, Handler: handler, NotifyStartedFunc: func() { close(started) }}
+	go srv.ActivateAndServe()
+	<-started
+
+	host, port, _ := net.SplitHostPort(pc.LocalAddr().String())
+	dnsConfMu.Lock()
+	prevConf := dnsConf
+	dnsConf = &dns.ClientConfig{Servers: []string{host}, Port: port}
+	dnsConfMu.Unlock()
+
+	t.Cleanup(func() {
+		dnsConfMu.Lock()
+		dnsConf = prevConf
+		dnsConfMu.Unlock()
+		srv.Shutdown()
+	})
+
+	return pc.LocalAddr().String()
+}
+
+// matchingRecords returns the records owned by name. If there are
+// none, the records of the closest wildcard, like "*.example.com.",
+// are returned with name as owner.
+func matchingRecords(rrs []dns.RR, name string) []dns.RR {
+	var matches []dns.RR
+	for _, rr := range rrs {
+		if strings.EqualFold(rr.Header().Name, name) {
+			matches = append(matches, rr)
+		}
+	}
+	if len(matches) > 0 {
+		return matches
+	}
+
+	for _, rr := range rrs {
+		owner := rr.Header().Name
+		if !strings.HasPrefix(owner, "*.") || !strings.HasSuffix(strings.ToLower(name), strings.ToLower(owner[1:])) {
+			continue
+		}
+		rr = dns.Copy(rr)
+		rr.Header().Name = name
+		matches = append(matches, rr)
+	}
+	return matches
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/distribution/reference"
+)
+
+// RegistryProvider identifies the cloud provider or service hosting a
+// Docker registry.
+type RegistryProvider string
+
+// Known registry providers.
+const (
+	UnknownRegistry  RegistryProvider = ""
+	ECR              RegistryProvider = "ECR"
+	GCR              RegistryProvider = "GCR"
+	ArtifactRegistry RegistryProvider = "ArtifactRegistry"
+	ACR              RegistryProvider = "ACR"
+	GHCR             RegistryProvider = "GHCR"
+	Quay             RegistryProvider = "Quay"
+)
+
+var (
+	// ecrRegexp matches private ECR registries, e.g.:
+	// 123456789012.dkr.ecr.eu-west-1.amazonaws.com.
+	ecrRegexp = regexp.MustCompile(`^([0-9]{12})\.dkr\.ecr(?:-fips)?\.([a-z0-9-]+)\.amazonaws\.com(\.cn)?$`)
+
+	// gcrRegexp matches Container Registry hosts, e.g.: eu.gcr.io.
+	gcrRegexp = regexp.MustCompile(`^(?:([a-z]+)\.)?gcr\.io$`)
+
+	// artifactRegistryRegexp matches Artifact Registry Docker hosts,
+	// e.g.: europe-west1-docker.pkg.dev.
+	artifactRegistryRegexp = regexp.MustCompile(`^([a-z0-9-]+)-docker\.pkg\.dev$`)
+
+	// acrRegexp matches Azure Container Registry hosts, e.g.:
+	// myregistry.azurecr.io.
+	acrRegexp = regexp.MustCompile(`^([a-z0-9]+)\.azurecr\.io$`)
+)
+
+// ImageReference is a parsed Docker image reference.
+type ImageReference struct {
+	// Registry is the registry host, including the port if any.
+	Registry string
+	// Repository is the path of the image inside the registry.
+	Repository string
+	// Tag is the image tag. It is empty if not specified.
+	Tag string
+	// Digest is the image digest. It is empty if not specified.
+	Digest string
+
+	// Provider is the provider hosting the registry.
+	Provider RegistryProvider
+	// Account is the owner of the registry or repository: the AWS
+	// account ID for ECR, the GCP project ID for GCR and Artifact
+	// Registry, the registry name for ACR and the organization for
+	// GHCR and Quay. It is empty if it cannot be determined.
+	Account string
+	// Region is the region hosting the registry. It is empty if the
+	// provider has no notion of region or it cannot be determined.
+	Region string
+}
+
+// ParseImageReference parses a Docker image reference and identifies
+// the provider hosting its registry. It returns error if the target
+// is not a Docker image according to [IsDockerImage].
+func ParseImageReference(target string) (ImageReference, error) {
+	if !IsDockerImage(target) {
+		return ImageReference{}, fmt.Errorf("not a docker image: %v", target)
+	}
+
+	n, err := reference.ParseNamed(target)
+	if err != nil {
+		return ImageReference{}, fmt.Errorf("parse docker image: %w", err)
+	}
+
+	ref := ImageReference{
+		Registry:   reference.Domain(n),
+		Repository: reference.Path(n),
+	}
+	if t, ok := n.(reference.Tagged); ok {
+		ref.Tag = t.Tag()
+	}
+	if d, ok := n.(reference.Digested); ok {
+		ref.Digest = d.Digest().String()
+	}
+
+	host := strings.ToLower(ref.Registry)
+	firstPath, _, _ := strings.Cut(ref.Repository, "/")
+
+	switch {
+	case ecrRegexp.MatchString(host):
+		m := ecrRegexp.FindStringSubmatch(host)
+		ref.Provider = ECR
+		ref.Account = m[1]
+		ref.Region = m[2]
+	case gcrRegexp.MatchString(host):
+		m := gcrRegexp.FindStringSubmatch(host)
+		ref.Provider = GCR
+		ref.Region = m[1]
+		if IsGCPProjectID(firstPath) {
+			ref.Account = firstPath
+		}
+	case artifactRegistryRegexp.MatchString(host):
+		m := artifactRegistryRegexp.FindStringSubmatch(host)
+		ref.Provider = ArtifactRegistry
+		ref.Region = m[1]
+		if IsGCPProjectID(firstPath) {
+			ref.Account = firstPath
+		}
+	case acrRegexp.MatchString(host):
+		m := acrRegexp.FindStringSubmatch(host)
+		ref.Provider = ACR
+		ref.Account = m[1]
+	case host == "ghcr.io":
+		ref.Provider = GHCR
+		if strings.Contains(ref.Repository, "/") {
+			ref.Account = firstPath
+		}
+	case host == "quay.io":
+		ref.Provider = Quay
+		if strings.Contains(ref.Repository, "/") {
+			ref.Account = firstPath
+		}
+	}
+
+	return ref, nil
+}
+
+// AWSAccountARN returns the ARN of the AWS account owning the image,
+// which is an [AWSAccount] asset identifier. It returns false if the
+// image is not hosted in ECR.
+func (r ImageReference) AWSAccountARN() (string, bool) {
+	if r.Provider != ECR || r.Account == "" {
+		return "", false
+	}
+
+	partition := "aws"
+	if strings.HasPrefix(r.Region, "cn-") {
+		partition = "aws-cn"
+	} else if strings.HasPrefix(r.Region, "us-gov-") {
+		partition = "aws-us-gov"
+	}
+
+	return fmt.Sprintf("arn:%v:iam::%v:root", partition, r.Account), true
+}
+
+// GCPProjectID returns the ID of the GCP project owning the image. It
+// returns false if the image is not hosted in GCR or Artifact Registry
+// or the project ID is not valid according to [IsGCPProjectID].
+func (r ImageReference) GCPProjectID() (string, bool) {
+	if r.Provider != GCR && r.Provider != ArtifactRegistry {
+		return "", false
+	}
+	if !IsGCPProjectID(r.Account) {
+		return "", false
+	}
+	return r.Account, true
+}
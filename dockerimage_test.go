@@ -0,0 +1,222 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseImageReference(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		want    ImageReference
+		wantErr bool
+	}{
+		{
+			name:   "ECR",
+			target: "123456789012.dkr.ecr.eu-west-1.amazonaws.com/team/app:1.0",
+			want: ImageReference{
+				Registry:   "123456789012.dkr.ecr.eu-west-1.amazonaws.com",
+				Repository: "team/app",
+				Tag:        "1.0",
+				Provider:   ECR,
+				Account:    "123456789012",
+				Region:     "eu-west-1",
+			},
+		},
+		{
+			name:   "ECR China",
+			target: "123456789012.dkr.ecr.cn-north-1.amazonaws.com.cn/app",
+			want: ImageReference{
+				Registry:   "123456789012.dkr.ecr.cn-north-1.amazonaws.com.cn",
+				Repository: "app",
+				Provider:   ECR,
+				Account:    "123456789012",
+				Region:     "cn-north-1",
+			},
+		},
+		{
+			name:   "GCR",
+			target: "eu.gcr.io/google-project/app:latest",
+			want: ImageReference{
+				Registry:   "eu.gcr.io",
+				Repository: "google-project/app",
+				Tag:        "latest",
+				Provider:   GCR,
+				Account:    "google-project",
+				Region:     "eu",
+			},
+		},
+		{
+			name:   "GCR invalid project",
+			target: "gcr.io/google_project/app",
+			want: ImageReference{
+				Registry:   "gcr.io",
+				Repository: "google_project/app",
+				Provider:   GCR,
+			},
+		},
+		{
+			name:   "Artifact Registry",
+			target: "europe-west1-docker.pkg.dev/google-project/repo/app@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			want: ImageReference{
+				Registry:   "europe-west1-docker.pkg.dev",
+				Repository: "google-project/repo/app",
+				Digest:     "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+				Provider:   ArtifactRegistry,
+				Account:    "google-project",
+				Region:     "europe-west1",
+			},
+		},
+		{
+			name:   "ACR",
+			target: "myregistry.azurecr.io/app:2",
+			want: ImageReference{
+				Registry:   "myregistry.azurecr.io",
+				Repository: "app",
+				Tag:        "2",
+				Provider:   ACR,
+				Account:    "myregistry",
+			},
+		},
+		{
+			name:   "GHCR",
+			target: "ghcr.io/puppeteer/puppeteer",
+			want: ImageReference{
+				Registry:   "ghcr.io",
+				Repository: "puppeteer/puppeteer",
+				Provider:   GHCR,
+				Account:    "puppeteer",
+			},
+		},
+		{
+			name:   "Quay",
+			target: "quay.io/coreos/etcd:v3.5.0",
+			want: ImageReference{
+				Registry:   "quay.io",
+				Repository: "coreos/etcd",
+				Tag:        "v3.5.0",
+				Provider:   Quay,
+				Account:    "coreos",
+			},
+		},
+		{
+			name:   "Unknown registry",
+			target: "localhost:5500/library/debian",
+			want: ImageReference{
+				Registry:   "localhost:5500",
+				Repository: "library/debian",
+			},
+		},
+		{
+			name:    "Not a docker image",
+			target:  "debian",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseImageReference(tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("image reference mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestImageReference_AWSAccountARN(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "ECR",
+			target: "123456789012.dkr.ecr.eu-west-1.amazonaws.com/app",
+			want:   "arn:aws:iam::123456789012:root",
+			wantOK: true,
+		},
+		{
+			name:   "ECR China",
+			target: "123456789012.dkr.ecr.cn-north-1.amazonaws.com.cn/app",
+			want:   "arn:aws-cn:iam::123456789012:root",
+			wantOK: true,
+		},
+		{
+			name:   "GCR",
+			target: "gcr.io/google-project/app",
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := ParseImageReference(tt.target)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, ok := ref.AWSAccountARN()
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("got (%v, %v), want (%v, %v)", got, ok, tt.want, tt.wantOK)
+			}
+			if ok && !IsAWSAccount(got) {
+				t.Errorf("%v is not an AWS account", got)
+			}
+		})
+	}
+}
+
+func TestImageReference_GCPProjectID(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "GCR",
+			target: "gcr.io/google-project/app",
+			want:   "google-project",
+			wantOK: true,
+		},
+		{
+			name:   "Artifact Registry",
+			target: "us-docker.pkg.dev/google-project/repo/app",
+			want:   "google-project",
+			wantOK: true,
+		},
+		{
+			name:   "GCR invalid project",
+			target: "gcr.io/google_project/app",
+			wantOK: false,
+		},
+		{
+			name:   "ECR",
+			target: "123456789012.dkr.ecr.eu-west-1.amazonaws.com/app",
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := ParseImageReference(tt.target)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, ok := ref.GCPProjectID()
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("got (%v, %v), want (%v, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DomainWildcard is a parsed [DomainPattern], like "*.example.com".
+type DomainWildcard struct {
+	// Domain is the domain under the wildcard label in A-label form,
+	// e.g. "example.com" for "*.example.com".
+	Domain string
+}
+
+// ParseDomainPattern parses a domain pattern. A domain pattern is a
+// hostname whose leftmost label is a wildcard ("*"), e.g.
+// "*.example.com". The pattern must contain a single wildcard, which
+// must be the whole leftmost label, and the domain under it must be a
+// valid hostname according to [ValidateHostname] that is not a public
+// suffix.
+func ParseDomainPattern(target string) (DomainWildcard, error) {
+	name := strings.TrimSuffix(target, ".")
+
+	domain, ok := strings.CutPrefix(name, "*.")
+	if !ok {
+		return DomainWildcard{}, fmt.Errorf("invalid domain pattern %q: it must start with \"*.\"", target)
+	}
+	if strings.Contains(domain, "*") {
+		return DomainWildcard{}, fmt.Errorf("invalid domain pattern %q: only a single leading wildcard is allowed", target)
+	}
+
+	if err := ValidateHostname(domain, HostnameOptions{}); err != nil {
+		return DomainWildcard{}, fmt.Errorf("invalid domain pattern %q: %w", target, err)
+	}
+
+	domain, err := toASCIIHostname(domain)
+	if err != nil {
+		return DomainWildcard{}, fmt.Errorf("invalid domain pattern %q: %w", target, err)
+	}
+
+	// Like certificate authorities do, reject wildcards directly
+	// under a top level domain or a public suffix.
+	if !strings.Contains(domain, ".") || IsPublicSuffix(domain) {
+		return DomainWildcard{}, fmt.Errorf("invalid domain pattern %q: %w", target, ErrPublicSuffix)
+	}
+
+	return DomainWildcard{Domain: domain}, nil
+}
+
+// IsDomainPattern returns true if the target is a valid domain
+// pattern according to [ParseDomainPattern].
+func IsDomainPattern(target string) bool {
+	_, err := ParseDomainPattern(target)
+	return err == nil
+}
+
+// String returns the string representation of the pattern.
+func (p DomainWildcard) String() string {
+	return "*." + p.Domain
+}
+
+// Matches reports whether the hostname matches the pattern following
+// the wildcard rules of TLS certificates (RFC 6125): the wildcard
+// matches exactly one non-empty label, so "*.example.com" matches
+// "www.example.com" but neither "example.com" nor
+// "a.www.example.com". The comparison is case-insensitive and
+// internationalized hostnames are compared in A-label form.
+func (p DomainWildcard) Matches(hostname string) bool {
+	if p.Domain == "" || IsIP(hostname) {
+		return false
+	}
+
+	name, err := toASCIIHostname(hostname)
+	if err != nil {
+		return false
+	}
+
+	label, ok := strings.CutSuffix(name, "."+p.Domain)
+	return ok && label != "" && !strings.Contains(label, ".")
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"testing"
+)
+
+func TestParseDomainPattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		want    DomainWildcard
+		wantErr bool
+	}{
+		{
+			name:   "Wildcard",
+			target: "*.example.com",
+			want:   DomainWildcard{Domain: "example.com"},
+		},
+		{
+			name:   "Uppercase and trailing dot",
+			target: "*.Example.COM.",
+			want:   DomainWildcard{Domain: "example.com"},
+		},
+		{
+			name:   "Internationalized",
+			target: "*.münchen.de",
+			want:   DomainWildcard{Domain: "xn--mnchen-3ya.de"},
+		},
+		{
+			name:    "No wildcard",
+			target:  "www.example.com",
+			wantErr: true,
+		},
+		{
+			name:    "Multiple wildcards",
+			target:  "*.*.example.com",
+			wantErr: true,
+		},
+		{
+			name:    "Non-leading wildcard",
+			target:  "www.*.example.com",
+			wantErr: true,
+		},
+		{
+			name:    "Partial wildcard",
+			target:  "w*.example.com",
+			wantErr: true,
+		},
+		{
+			name:    "Wildcard only",
+			target:  "*",
+			wantErr: true,
+		},
+		{
+			name:    "Top level domain",
+			target:  "*.com",
+			wantErr: true,
+		},
+		{
+			name:    "Public suffix",
+			target:  "*.co.uk",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid domain",
+			target:  "*.exa mple.com",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDomainPattern(tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDomainWildcard_Matches(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		hostname string
+		want     bool
+	}{
+		{
+			name:     "Single label",
+			pattern:  "*.example.com",
+			hostname: "www.example.com",
+			want:     true,
+		},
+		{
+			name:     "Case insensitive",
+			pattern:  "*.example.com",
+			hostname: "WWW.EXAMPLE.COM.",
+			want:     true,
+		},
+		{
+			name:     "Internationalized",
+			pattern:  "*.münchen.de",
+			hostname: "www.xn--mnchen-3ya.de",
+			want:     true,
+		},
+		{
+			name:     "Base domain",
+			pattern:  "*.example.com",
+			hostname: "example.com",
+			want:     false,
+		},
+		{
+			name:     "Multiple labels",
+			pattern:  "*.example.com",
+			hostname: "a.www.example.com",
+			want:     false,
+		},
+		{
+			name:     "Different domain",
+			pattern:  "*.example.com",
+			hostname: "www.badexample.com",
+			want:     false,
+		},
+		{
+			name:     "Wildcard hostname",
+			pattern:  "*.example.com",
+			hostname: "*.example.com",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ParseDomainPattern(tt.pattern)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := p.Matches(tt.hostname); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
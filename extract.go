@@ -0,0 +1,182 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ExtractedAsset is an asset found in a text by [ExtractAssets].
+type ExtractedAsset struct {
+	// Identifier is the asset identifier.
+	Identifier string
+	// Start is the byte offset of the identifier in the text.
+	Start int
+	// End is the byte offset of the end of the identifier in the
+	// text, so text[Start:End] == Identifier.
+	End int
+	// Types contains the detected asset types.
+	Types []AssetType
+}
+
+// extractPatterns contains the regular expressions used to find
+// candidate assets in a text, in the order they are tried. Text
+// matched by a pattern is not considered by the following ones.
+var extractPatterns = []*regexp.Regexp{
+	// URLs, including Git repositories.
+	regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s<>"'` + "`" + `]+`),
+	// scp-like Git repositories.
+	regexp.MustCompile(`[a-zA-Z0-9._-]+@[a-zA-Z0-9.-]+:[a-zA-Z0-9._~/-]+\.git`),
+	// ARNs.
+	regexp.MustCompile(`arn:[a-z-]+:[a-z0-9-]*:[a-z0-9-]*:[0-9]*:[^\s"'` + "`" + `]+`),
+	// IPv4 addresses and CIDRs.
+	regexp.MustCompile(`[0-9]{1,3}(?:\.[0-9]{1,3}){3}(?:/[0-9]{1,2})?`),
+	// IPv6 addresses and CIDRs.
+	regexp.MustCompile(`[0-9a-fA-F]{0,4}(?::[0-9a-fA-F]{0,4}){2,7}(?:/[0-9]{1,3})?`),
+	// Docker images.
+	regexp.MustCompile(`[a-zA-Z0-9.-]+(?::[0-9]+)?/[a-z0-9._/-]+(?::[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127})?(?:@sha256:[a-f0-9]{64})?`),
+	// Hostnames.
+	regexp.MustCompile(`(?:[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)+[a-zA-Z][a-zA-Z0-9-]{0,61}[a-zA-Z0-9]`),
+}
+
+// ExtractAssets finds the assets referenced in a free-form text, like
+// a security report or a ticket. It looks for IPs, CIDRs, web and
+// service URLs, AWS account ARNs, Docker images, Git repositories and
+// hostnames, and validates every candidate with the corresponding
+// checker.
+//
+// No network access is performed: hostnames are validated with
+// [IsHostnameNoDNSResolution] and must end in a known public suffix,
+// so names like "file.txt" are ignored. ARNs that do not identify an
+// AWS account are not reported. The assets are returned in the order
+// they appear in the text.
+func ExtractAssets(text string) []ExtractedAsset {
+	var (
+		assets []ExtractedAsset
+		used   = make([]bool, len(text))
+	)
+
+	for _, re := range extractPatterns {
+		for _, loc := range re.FindAllStringIndex(text, -1) {
+			start, end := loc[0], trimTrailingPunctuation(text, loc[0], loc[1])
+			if start >= end || !isTokenBoundary(text, start, end) {
+				continue
+			}
+			if overlaps(used, start, end) {
+				continue
+			}
+
+			identifier := text[start:end]
+			types := extractedAssetTypes(identifier)
+			if len(types) == 0 {
+				continue
+			}
+
+			for i := start; i < end; i++ {
+				used[i] = true
+			}
+			assets = append(assets, ExtractedAsset{
+				Identifier: identifier,
+				Start:      start,
+				End:        end,
+				Types:      types,
+			})
+		}
+	}
+
+	sort.Slice(assets, func(i, j int) bool {
+		return assets[i].Start < assets[j].Start
+	})
+	return assets
+}
+
+// extractedAssetTypes returns the asset types of an identifier found
+// in a text, without performing any network access.
+func extractedAssetTypes(identifier string) []AssetType {
+	var types []AssetType
+	if IsAWSAccount(identifier) {
+		types = append(types, AWSAccount)
+	}
+	if IsDockerImage(identifier) {
+		types = append(types, DockerImage)
+	}
+	if IsGitRepository(identifier) {
+		types = append(types, GitRepository)
+	}
+	if IsIP(identifier) || IsHost(identifier) {
+		types = append(types, IP)
+	} else if IsCIDR(identifier) {
+		types = append(types, IPRange)
+	}
+	if IsWebAddress(identifier) {
+		types = append(types, WebAddress)
+	}
+	if IsServiceURL(identifier) {
+		types = append(types, ServiceURL)
+	}
+	if isKnownHostname(identifier) {
+		types = append(types, Hostname)
+	}
+	return types
+}
+
+// isKnownHostname returns true if target is a valid hostname ending
+// in a known public suffix.
+func isKnownHostname(target string) bool {
+	if !IsHostnameNoDNSResolution(target) {
+		return false
+	}
+	name, err := normalizeDomainName(target)
+	if err != nil {
+		return false
+	}
+	suffix, explicit := currentPublicSuffixList().publicSuffix(name)
+	return explicit && suffix != name
+}
+
+// trimTrailingPunctuation returns the end of the match once the
+// trailing punctuation, which is usually part of the surrounding
+// text, is removed.
+func trimTrailingPunctuation(text string, start, end int) int {
+	for end > start && strings.ContainsRune(".,;:!?)]}>'\"", rune(text[end-1])) {
+		end--
+	}
+	return end
+}
+
+// isTokenBoundary reports whether the match is not part of a longer
+// word. A trailing "." or ":" is considered punctuation only if it is
+// not followed by a letter, a digit or an underscore, so "1.2.3.4" is
+// not extracted from "1.2.3.4.5".
+func isTokenBoundary(text string, start, end int) bool {
+	isAlnum := func(c byte) bool {
+		return c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+	}
+	isWordChar := func(c byte) bool {
+		return isAlnum(c) || c == '-' || c == '.' || c == '/' || c == '@' || c == ':'
+	}
+	if start > 0 && isWordChar(text[start-1]) {
+		return false
+	}
+	if end >= len(text) || !isWordChar(text[end]) {
+		return true
+	}
+	if text[end] != '.' && text[end] != ':' {
+		return false
+	}
+	return end+1 >= len(text) || !isAlnum(text[end+1])
+}
+
+// overlaps reports whether any byte in [start, end) is already used.
+func overlaps(used []bool, start, end int) bool {
+	for i := start; i < end; i++ {
+		if used[i] {
+			return true
+		}
+	}
+	return false
+}
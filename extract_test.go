@@ -0,0 +1,88 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExtractAssets(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []ExtractedAsset
+	}{
+		{
+			name: "Hostname, IP and Docker image",
+			text: "the host api.example.com at 10.0.0.4 running ghcr.io/org/img:1.2",
+			want: []ExtractedAsset{
+				{Identifier: "api.example.com", Start: 9, End: 24, Types: []AssetType{Hostname}},
+				{Identifier: "10.0.0.4", Start: 28, End: 36, Types: []AssetType{IP}},
+				{Identifier: "ghcr.io/org/img:1.2", Start: 45, End: 64, Types: []AssetType{DockerImage}},
+			},
+		},
+		{
+			name: "URLs and trailing punctuation",
+			text: "See https://www.example.com/login?next=/, and (http://192.0.2.1:8080/admin).",
+			want: []ExtractedAsset{
+				{Identifier: "https://www.example.com/login?next=/", Start: 4, End: 40, Types: []AssetType{WebAddress}},
+				{Identifier: "http://192.0.2.1:8080/admin", Start: 47, End: 74, Types: []AssetType{WebAddress}},
+			},
+		},
+		{
+			name: "Git repositories",
+			text: "Cloned git@github.com:adevinta/vulcan-types.git and https://github.com/adevinta/vulcan-agent.git.",
+			want: []ExtractedAsset{
+				{Identifier: "git@github.com:adevinta/vulcan-types.git", Start: 7, End: 47, Types: []AssetType{GitRepository}},
+				{Identifier: "https://github.com/adevinta/vulcan-agent.git", Start: 52, End: 96, Types: []AssetType{GitRepository, WebAddress}},
+			},
+		},
+		{
+			name: "ARNs",
+			text: "Accounts arn:aws:iam::123456789012:root and arn:aws:s3:::bucket_name.",
+			want: []ExtractedAsset{
+				{Identifier: "arn:aws:iam::123456789012:root", Start: 9, End: 39, Types: []AssetType{AWSAccount}},
+			},
+		},
+		{
+			name: "CIDRs and IPv6",
+			text: "Ranges 192.0.2.0/24, 192.0.2.1/32 and 2001:db8::/48; host 2001:db8::1 at 12:30:45.",
+			want: []ExtractedAsset{
+				{Identifier: "192.0.2.0/24", Start: 7, End: 19, Types: []AssetType{IPRange}},
+				{Identifier: "192.0.2.1/32", Start: 21, End: 33, Types: []AssetType{IP}},
+				{Identifier: "2001:db8::/48", Start: 38, End: 51, Types: []AssetType{IPRange}},
+				{Identifier: "2001:db8::1", Start: 58, End: 69, Types: []AssetType{IP}},
+			},
+		},
+		{
+			name: "Hostname at the end of a sentence",
+			text: "It is served by www.example.co.uk.",
+			want: []ExtractedAsset{
+				{Identifier: "www.example.co.uk", Start: 16, End: 33, Types: []AssetType{Hostname}},
+			},
+		},
+		{
+			name: "Not assets",
+			text: "Open file.txt, mail admin@example.com, version 1.2.3, build 1.2.3.4.5 and std::vector.",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractAssets(tt.text)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("assets mismatch (-want +got):\n%v", diff)
+			}
+			for _, a := range got {
+				if tt.text[a.Start:a.End] != a.Identifier {
+					t.Errorf("wrong offsets for %v: %v", a.Identifier, tt.text[a.Start:a.End])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+// RelationKind describes how two related assets are linked.
+type RelationKind string
+
+// Relation kinds between assets.
+const (
+	// HostedOn links a WebAddress, DockerImage, GitRepository,
+	// ServiceURL or NetworkService to the Hostname or IP serving it.
+	HostedOn RelationKind = "HostedOn"
+	// PartOf links a Hostname to the DomainName it belongs to.
+	PartOf RelationKind = "PartOf"
+	// ContainedIn links an IP to an IPRange containing it.
+	ContainedIn RelationKind = "ContainedIn"
+	// OwnedBy links a DockerImage to the AWSAccount owning its
+	// registry.
+	OwnedBy RelationKind = "OwnedBy"
+)
+
+// Asset is an identifier together with its asset type.
+type Asset struct {
+	Identifier string
+	Type       AssetType
+}
+
+// AssetRelation is a directed edge between two assets.
+type AssetRelation struct {
+	From Asset
+	To   Asset
+	Kind RelationKind
+}
+
+// AssetGraph contains a set of assets and the relations between them.
+type AssetGraph struct {
+	Assets    []Asset
+	Relations []AssetRelation
+}
+
+// DetectAssetGraph detects the asset types of the provided identifiers
+// and returns a graph with the detected assets, the assets derived
+// from them and the relations between all of them:
+//
+//   - WebAddress → Hostname or IP (HostedOn)
+//   - DockerImage → registry Hostname (HostedOn)
+//   - DockerImage → AWSAccount owning an ECR registry (OwnedBy)
+//   - GitRepository → Hostname (HostedOn)
+//   - ServiceURL and NetworkService → Hostname or IP (HostedOn)
+//   - Hostname → DomainName (PartOf)
+//   - IP → IPRange (ContainedIn)
+//
+// Unlike [DetectAssetTypes], every asset in the graph carries its own
+// identifier. For instance, the identifier "https://adevinta.com"
+// produces the WebAddress "https://adevinta.com", the Hostname
+// "adevinta.com" and the DomainName "adevinta.com".
+func DetectAssetGraph(identifiers ...string) (AssetGraph, error) {
+	return DetectAssetGraphWithOptions(DetectOptions{}, identifiers...)
+}
+
+// DetectAssetGraphWithOptions is like [DetectAssetGraph] but allows to
+// configure the detection. See [DetectOptions].
+//
+// The asset types are detected with [DetectAssetTypesWithOptions], so
+// custom asset types registered with [Register] are included in the
+// graph, although no assets are derived from them. GCP projects owning
+// Docker images are not asset types and are not included, use
+// [ImageReference.GCPProjectID] to get them.
+func DetectAssetGraphWithOptions(opts DetectOptions, identifiers ...string) (AssetGraph, error) {
+	b := &graphBuilder{
+		opts:     opts,
+		index:    make(map[Asset]bool),
+		relIndex: make(map[AssetRelation]bool),
+	}
+	for _, identifier := range identifiers {
+		if err := b.detect(identifier); err != nil {
+			return AssetGraph{}, err
+		}
+	}
+	b.linkIPRanges()
+	return b.graph, nil
+}
+
+// graphBuilder builds an [AssetGraph] avoiding duplicated assets and
+// relations.
+type graphBuilder struct {
+	opts     DetectOptions
+	graph    AssetGraph
+	index    map[Asset]bool
+	relIndex map[AssetRelation]bool
+}
+
+func (b *graphBuilder) addAsset(a Asset) {
+	if b.index[a] {
+		return
+	}
+	b.index[a] = true
+	b.graph.Assets = append(b.graph.Assets, a)
+}
+
+func (b *graphBuilder) addRelation(from, to Asset, kind RelationKind) {
+	b.addAsset(from)
+	b.addAsset(to)
+	rel := AssetRelation{From: from, To: to, Kind: kind}
+	if b.relIndex[rel] {
+		return
+	}
+	b.relIndex[rel] = true
+	b.graph.Relations = append(b.graph.Relations, rel)
+}
+
+// detect detects the asset types of the identifier and adds the
+// corresponding assets to the graph.
+func (b *graphBuilder) detect(identifier string) error {
+	assetTypes, err := DetectAssetTypesWithOptions(identifier, b.opts)
+	if err != nil {
+		return err
+	}
+
+	// The Hostname, DomainName and IP types detected for a web
+	// address refer to its host, which is derived from the
+	// WebAddress asset.
+	for _, t := range assetTypes {
+		if t == WebAddress {
+			assetTypes = []AssetType{WebAddress}
+			break
+		}
+	}
+
+	for _, t := range assetTypes {
+		if err := b.derive(identifier, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// derive adds the asset with the provided identifier and type to the
+// graph, together with the assets derived from it.
+func (b *graphBuilder) derive(identifier string, t AssetType) error {
+	asset := Asset{Identifier: identifier, Type: t}
+
+	switch t {
+	case IP:
+		// In case the CIDR has a /32 mask, remove the mask and
+		// add the asset as an IP.
+		asset.Identifier = strings.TrimSuffix(identifier, "/32")
+		b.addAsset(asset)
+	case Hostname:
+		return b.linkDomainName(asset)
+	case WebAddress:
+		u, err := url.ParseRequestURI(identifier)
+		if err != nil {
+			return err
+		}
+		b.addAsset(asset)
+		return b.linkHost(asset, u.Hostname())
+	case DockerImage:
+		ref, err := ParseImageReference(identifier)
+		if err != nil {
+			return err
+		}
+		b.addAsset(asset)
+		if arn, ok := ref.AWSAccountARN(); ok {
+			b.addRelation(asset, Asset{Identifier: arn, Type: AWSAccount}, OwnedBy)
+		}
+		return b.linkHost(asset, ref.Registry)
+	case GitRepository:
+		b.addAsset(asset)
+		return b.linkHost(asset, gitRepositoryHost(identifier))
+	case ServiceURL:
+		ref, err := ParseServiceURL(identifier)
+		if err != nil {
+			return err
+		}
+
+		// Service URLs are added without credentials.
+		asset.Identifier = ref.String()
+		b.addAsset(asset)
+		return b.linkHost(asset, ref.Host)
+	case NetworkService:
+		addr, err := ParseNetworkService(identifier)
+		if err != nil {
+			return err
+		}
+		b.addAsset(asset)
+		return b.linkHost(asset, addr.Host)
+	default:
+		b.addAsset(asset)
+	}
+	return nil
+}
+
+// linkHost adds a HostedOn relation between from and the provided
+// host. If the host is an IP, it is linked as an IP asset. Otherwise,
+// it is linked as a Hostname, if it resolves, and the hostname is
+// linked to its domain name.
+func (b *graphBuilder) linkHost(from Asset, host string) error {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	if host == "" {
+		return nil
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil {
+		ip := Asset{Identifier: addr.WithZone("").String(), Type: IP}
+		b.addRelation(from, ip, HostedOn)
+		return nil
+	}
+
+	if !b.opts.isHostname(host) {
+		return nil
+	}
+	hostname := Asset{Identifier: host, Type: Hostname}
+	b.addRelation(from, hostname, HostedOn)
+	return b.linkDomainName(hostname)
+}
+
+// linkDomainName adds a PartOf relation between the hostname and the
+// closest domain name containing it, if any.
+func (b *graphBuilder) linkDomainName(hostname Asset) error {
+	b.addAsset(hostname)
+
+	labels := strings.Split(strings.TrimSuffix(hostname.Identifier, "."), ".")
+	for i := range labels {
+		// Do not walk up to public suffixes.
+		name := strings.Join(labels[i:], ".")
+		if IsPublicSuffix(name) {
+			break
+		}
+
+		ok, err := b.opts.isDomainName(name)
+		if err != nil {
+			return fmt.Errorf("%w: cannot guess if the asset is a domain: %w", ErrDNSQuery, err)
+		}
+		if ok {
+			b.addRelation(hostname, Asset{Identifier: name, Type: DomainName}, PartOf)
+			return nil
+		}
+	}
+	return nil
+}
+
+// linkIPRanges adds a ContainedIn relation between every IP and every
+// IPRange of the graph containing it.
+func (b *graphBuilder) linkIPRanges() {
+	var ips, ranges []Asset
+	for _, a := range b.graph.Assets {
+		switch a.Type {
+		case IP:
+			ips = append(ips, a)
+		case IPRange:
+			ranges = append(ranges, a)
+		}
+	}
+
+	for _, r := range ranges {
+		_, ipnet, err := net.ParseCIDR(r.Identifier)
+		if err != nil {
+			continue
+		}
+		for _, ip := range ips {
+			if ipnet.Contains(net.ParseIP(ip.Identifier)) {
+				b.addRelation(ip, r, ContainedIn)
+			}
+		}
+	}
+}
+
+// gitRepositoryHost returns the host of a Git repository URL. It
+// supports both URLs with scheme and scp-like addresses such as
+// "git@github.com:adevinta/vulcan-types.git".
+func gitRepositoryHost(target string) string {
+	if strings.Contains(target, "://") {
+		u, err := url.Parse(target)
+		if err != nil {
+			return ""
+		}
+		return u.Hostname()
+	}
+
+	if _, after, ok := strings.Cut(target, "@"); ok {
+		target = after
+	}
+	host, _, _ := strings.Cut(target, ":")
+	return host
+}
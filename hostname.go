@@ -0,0 +1,133 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// maxHostnameLength is the maximum length of a hostname, without
+	// the trailing dot, in its textual representation.
+	maxHostnameLength = 253
+
+	// maxLabelLength is the maximum length of a hostname label.
+	maxLabelLength = 63
+)
+
+// HostnameOptions customizes the rules applied by [ValidateHostname].
+type HostnameOptions struct {
+	// AllowUnderscore allows underscores in labels, as used by
+	// service labels like "_sip._tcp.example.com" or
+	// "_dmarc.example.com".
+	AllowUnderscore bool
+}
+
+// HostnameError is returned by [ValidateHostname] when a hostname is
+// not valid.
+type HostnameError struct {
+	// Hostname is the validated hostname.
+	Hostname string
+	// Label is the offending label, if the error is specific to a
+	// label.
+	Label string
+	// Reason describes why the hostname is not valid.
+	Reason string
+}
+
+// Error returns the error message.
+func (e *HostnameError) Error() string {
+	if e.Label != "" {
+		return fmt.Sprintf("invalid hostname %q: label %q: %v", e.Hostname, e.Label, e.Reason)
+	}
+	return fmt.Sprintf("invalid hostname %q: %v", e.Hostname, e.Reason)
+}
+
+// ValidateHostname checks that the target is a syntactically valid
+// hostname according to RFC 952, RFC 1123 and RFC 3696:
+//
+//   - It is at most 253 characters long, excluding the optional
+//     trailing dot.
+//   - Every label is between 1 and 63 characters long.
+//   - Labels only contain letters, digits and hyphens (LDH), and
+//     underscores if [HostnameOptions.AllowUnderscore] is set.
+//   - Labels do not start or end with a hyphen.
+//   - The top level label is not all-numeric.
+//
+// Internationalized hostnames are converted to their A-label form
+// before being checked. It returns a [*HostnameError] describing the
+// reason if the hostname is not valid.
+func ValidateHostname(target string, opts HostnameOptions) error {
+	name := target
+	if !isASCII(name) {
+		ascii, err := toASCIILookupName(name)
+		if err != nil {
+			return &HostnameError{Hostname: target, Reason: fmt.Sprintf("invalid internationalized name: %v", err)}
+		}
+		name = ascii
+	}
+
+	// A single trailing dot denotes the root zone.
+	name = strings.TrimSuffix(name, ".")
+
+	if name == "" {
+		return &HostnameError{Hostname: target, Reason: "empty hostname"}
+	}
+	if len(name) > maxHostnameLength {
+		return &HostnameError{
+			Hostname: target,
+			Reason:   fmt.Sprintf("length %v exceeds the maximum of %v", len(name), maxHostnameLength),
+		}
+	}
+
+	labels := strings.Split(name, ".")
+	for _, label := range labels {
+		if err := validateLabel(label, opts); err != "" {
+			return &HostnameError{Hostname: target, Label: label, Reason: err}
+		}
+	}
+
+	if tld := labels[len(labels)-1]; strings.Trim(tld, "0123456789") == "" {
+		return &HostnameError{Hostname: target, Label: tld, Reason: "top level label is all-numeric"}
+	}
+
+	return nil
+}
+
+// validateLabel returns the reason why label is not a valid hostname
+// label or an empty string if it is valid.
+func validateLabel(label string, opts HostnameOptions) string {
+	if label == "" {
+		return "empty label"
+	}
+	if len(label) > maxLabelLength {
+		return fmt.Sprintf("length %v exceeds the maximum of %v", len(label), maxLabelLength)
+	}
+	if label[0] == '-' || label[len(label)-1] == '-' {
+		return "starts or ends with a hyphen"
+	}
+
+	for _, r := range label {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-':
+		case r == '_' && opts.AllowUnderscore:
+		default:
+			return fmt.Sprintf("invalid character %q", r)
+		}
+	}
+	return ""
+}
+
+// isASCII returns true if s only contains ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
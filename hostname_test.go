@@ -0,0 +1,131 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateHostname(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		opts       HostnameOptions
+		wantReason string
+	}{
+		{
+			name:   "Hostname",
+			target: "www.adevinta.com",
+		},
+		{
+			name:   "Single label",
+			target: "localhost",
+		},
+		{
+			name:   "Trailing dot",
+			target: "www.adevinta.com.",
+		},
+		{
+			name:   "Uppercase",
+			target: "WWW.Adevinta.COM",
+		},
+		{
+			name:   "Internationalized",
+			target: "münchen.de",
+		},
+		{
+			name:       "Empty",
+			target:     "",
+			wantReason: "empty hostname",
+		},
+		{
+			name:       "Only trailing dot",
+			target:     ".",
+			wantReason: "empty hostname",
+		},
+		{
+			name:       "Empty label",
+			target:     "foo..bar",
+			wantReason: "empty label",
+		},
+		{
+			name:       "Leading dot",
+			target:     ".foo.bar",
+			wantReason: "empty label",
+		},
+		{
+			name:       "Two trailing dots",
+			target:     "foo.bar..",
+			wantReason: "empty label",
+		},
+		{
+			name:       "Leading hyphen",
+			target:     "-bad-.com",
+			wantReason: "starts or ends with a hyphen",
+		},
+		{
+			name:       "Space",
+			target:     "a b.com",
+			wantReason: `invalid character ' '`,
+		},
+		{
+			name:       "Underscore",
+			target:     "_dmarc.adevinta.com",
+			wantReason: `invalid character '_'`,
+		},
+		{
+			name:   "Underscore allowed",
+			target: "_sip._tcp.adevinta.com",
+			opts:   HostnameOptions{AllowUnderscore: true},
+		},
+		{
+			name:       "Internationalized underscore",
+			target:     "_sip.münchen.de",
+			wantReason: `invalid character '_'`,
+		},
+		{
+			name:   "Internationalized underscore allowed",
+			target: "_sip.münchen.de",
+			opts:   HostnameOptions{AllowUnderscore: true},
+		},
+		{
+			name:       "Long label",
+			target:     strings.Repeat("a", 64) + ".com",
+			wantReason: "length 64 exceeds the maximum of 63",
+		},
+		{
+			name:       "Long hostname",
+			target:     strings.Repeat(strings.Repeat("a", 63)+".", 4) + "com",
+			wantReason: "length 259 exceeds the maximum of 253",
+		},
+		{
+			name:       "All-numeric TLD",
+			target:     "192.0.2.1",
+			wantReason: "top level label is all-numeric",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateHostname(tt.target, tt.opts)
+			if tt.wantReason == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var herr *HostnameError
+			if !errors.As(err, &herr) {
+				t.Fatalf("got error %v, want a *HostnameError", err)
+			}
+			if herr.Reason != tt.wantReason {
+				t.Errorf("got reason %q, want %q", herr.Reason, tt.wantReason)
+			}
+		})
+	}
+}
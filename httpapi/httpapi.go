@@ -0,0 +1,305 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+// Package httpapi exposes the asset type detection of the types
+// package as a JSON API over HTTP, so it can be used by services not
+// written in Go.
+//
+// The API provides the following endpoints:
+//
+//   - POST /detect detects the asset types of a list of identifiers.
+//   - POST /validate validates a list of identifiers against their
+//     declared asset types.
+//   - GET /types returns the known asset types.
+//   - GET /openapi.json returns the OpenAPI document of the API.
+package httpapi
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	types "github.com/adevinta/vulcan-types"
+)
+
+// Default limits used by [NewHandler].
+const (
+	DefaultMaxBodyBytes   = 1 << 20
+	DefaultMaxIdentifiers = 1000
+	DefaultTimeout        = 30 * time.Second
+)
+
+//go:embed openapi.json
+var openAPIDocument []byte
+
+// Options configures the handler returned by [NewHandler].
+type Options struct {
+	// MaxBodyBytes is the maximum size of a request body. It defaults
+	// to DefaultMaxBodyBytes.
+	MaxBodyBytes int64
+
+	// MaxIdentifiers is the maximum number of identifiers in a
+	// request. It defaults to DefaultMaxIdentifiers.
+	MaxIdentifiers int
+
+	// Timeout is the maximum time spent processing a request. It
+	// defaults to DefaultTimeout. When it expires, or the client
+	// cancels the request, the remaining identifiers are not
+	// processed and the handler responds immediately. However, the
+	// DNS queries already in flight are not cancelled, so they keep
+	// running in the background until they finish or time out.
+	Timeout time.Duration
+
+	// Detect configures the detection and validation of asset types.
+	Detect types.DetectOptions
+}
+
+// DetectRequest is the body of a POST /detect request.
+type DetectRequest struct {
+	Identifiers []string `json:"identifiers"`
+}
+
+// DetectResult is the detection result of an identifier.
+type DetectResult struct {
+	Identifier string            `json:"identifier"`
+	Types      []types.AssetType `json:"types"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// DetectResponse is the body of a POST /detect response.
+type DetectResponse struct {
+	Results []DetectResult `json:"results"`
+}
+
+// Asset is an identifier with its declared asset type. The type is a
+// plain string, so unknown asset types are reported in the
+// corresponding [ValidateResult] instead of failing the whole request.
+type Asset struct {
+	Identifier string `json:"identifier"`
+	Type       string `json:"type"`
+}
+
+// ValidateRequest is the body of a POST /validate request.
+type ValidateRequest struct {
+	Assets []Asset `json:"assets"`
+}
+
+// ValidateResult is the validation result of an asset.
+type ValidateResult struct {
+	Identifier string `json:"identifier"`
+	Type       string `json:"type"`
+	Valid      bool   `json:"valid"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ValidateResponse is the body of a POST /validate response.
+type ValidateResponse struct {
+	Results []ValidateResult `json:"results"`
+}
+
+// AssetType describes an asset type.
+type AssetType struct {
+	Name             types.AssetType `json:"name"`
+	DisplayName      string          `json:"display_name,omitempty"`
+	Description      string          `json:"description,omitempty"`
+	Examples         []string        `json:"examples,omitempty"`
+	RequiresNetwork  bool            `json:"requires_network"`
+	NetworkReachable bool            `json:"network_reachable"`
+}
+
+// TypesResponse is the body of a GET /types response.
+type TypesResponse struct {
+	Types []AssetType `json:"types"`
+}
+
+// ErrorResponse is the body of a response to a failed request.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// handler implements the API.
+type handler struct {
+	opts Options
+	mux  *http.ServeMux
+}
+
+// NewHandler returns an [http.Handler] that serves the API.
+func NewHandler(opts Options) http.Handler {
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if opts.MaxIdentifiers <= 0 {
+		opts.MaxIdentifiers = DefaultMaxIdentifiers
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+
+	h := &handler{opts: opts, mux: http.NewServeMux()}
+	h.mux.HandleFunc("POST /detect", h.detect)
+	h.mux.HandleFunc("POST /validate", h.validate)
+	h.mux.HandleFunc("GET /types", h.types)
+	h.mux.HandleFunc("GET /openapi.json", h.openAPI)
+	return h
+}
+
+// ServeHTTP implements [http.Handler].
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *handler) detect(w http.ResponseWriter, r *http.Request) {
+	var req DetectRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+	if !h.checkLimit(w, len(req.Identifiers)) {
+		return
+	}
+
+	resp := DetectResponse{Results: make([]DetectResult, 0, len(req.Identifiers))}
+	err := h.process(r.Context(), len(req.Identifiers), func(i int) {
+		res := DetectResult{Identifier: req.Identifiers[i]}
+		assetTypes, err := types.DetectAssetTypesWithOptions(res.Identifier, h.opts.Detect)
+		switch {
+		case err != nil:
+			res.Error = err.Error()
+		case len(assetTypes) == 0:
+			res.Error = types.ErrNoAssetType.Error()
+		default:
+			res.Types = assetTypes
+		}
+		resp.Results = append(resp.Results, res)
+	})
+	if err != nil {
+		writeError(w, http.StatusGatewayTimeout, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *handler) validate(w http.ResponseWriter, r *http.Request) {
+	var req ValidateRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+	if !h.checkLimit(w, len(req.Assets)) {
+		return
+	}
+
+	resp := ValidateResponse{Results: make([]ValidateResult, 0, len(req.Assets))}
+	err := h.process(r.Context(), len(req.Assets), func(i int) {
+		asset := req.Assets[i]
+		res := ValidateResult{Identifier: asset.Identifier, Type: asset.Type}
+		if err := types.ValidateWithOptions(asset.Identifier, types.AssetType(asset.Type), h.opts.Detect); err != nil {
+			res.Error = err.Error()
+		} else {
+			res.Valid = true
+		}
+		resp.Results = append(resp.Results, res)
+	})
+	if err != nil {
+		writeError(w, http.StatusGatewayTimeout, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *handler) types(w http.ResponseWriter, r *http.Request) {
+	var resp TypesResponse
+	for _, spec := range types.AllAssetTypes() {
+		resp.Types = append(resp.Types, AssetType{
+			Name:             spec.Name,
+			DisplayName:      spec.DisplayName,
+			Description:      spec.Description,
+			Examples:         spec.Examples,
+			RequiresNetwork:  spec.RequiresNetwork,
+			NetworkReachable: spec.NetworkReachable,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *handler) openAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPIDocument)
+}
+
+// decode decodes the JSON body of the request into v. If the body
+// cannot be decoded, it writes an error response and returns false.
+func (h *handler) decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, h.opts.MaxBodyBytes)
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("request body too large: limit is %v bytes", maxErr.Limit))
+			return false
+		}
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return false
+	}
+	return true
+}
+
+// checkLimit checks the number of identifiers in a request. If the
+// limit is exceeded, it writes an error response and returns false.
+func (h *handler) checkLimit(w http.ResponseWriter, n int) bool {
+	if n > h.opts.MaxIdentifiers {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("too many identifiers: limit is %v", h.opts.MaxIdentifiers))
+		return false
+	}
+	return true
+}
+
+// process calls fn for every index in [0, n) in a separate goroutine.
+// It returns an error if the request is not processed before the
+// configured timeout or the request context is done. In that case, fn
+// is not called for the remaining indexes.
+func (h *handler) process(ctx context.Context, n int, fn func(i int)) error {
+	ctx, cancel := context.WithTimeout(ctx, h.opts.Timeout)
+	defer cancel()
+
+	var (
+		done      = make(chan struct{})
+		completed bool
+	)
+	go func() {
+		defer close(done)
+		for i := 0; i < n; i++ {
+			if ctx.Err() != nil {
+				return
+			}
+			fn(i)
+		}
+		completed = true
+	}()
+
+	select {
+	case <-done:
+		if !completed {
+			return fmt.Errorf("request not processed: %w", ctx.Err())
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("request not processed: %w", ctx.Err())
+	}
+}
+
+// writeJSON writes v as the JSON body of the response.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, ErrorResponse{Error: err.Error()})
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	types "github.com/adevinta/vulcan-types"
+)
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       Options
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "detect",
+			method:     http.MethodPost,
+			path:       "/detect",
+			body:       `{"identifiers": ["192.0.2.1", "192.0.2.0/24", "adevinta.com", "not an asset"]}`,
+			wantStatus: http.StatusOK,
+			wantBody: `{"results": [
+				{"identifier": "192.0.2.1", "types": ["IP"]},
+				{"identifier": "192.0.2.0/24", "types": ["IPRange"]},
+				{"identifier": "adevinta.com", "types": ["Hostname", "DomainName"]},
+				{"identifier": "not an asset", "types": null, "error": "no asset type detected"}
+			]}`,
+		},
+		{
+			name:       "validate",
+			method:     http.MethodPost,
+			path:       "/validate",
+			body:       `{"assets": [{"identifier": "192.0.2.1", "type": "IP"}, {"identifier": "192.0.2.1", "type": "Hostnme"}]}`,
+			wantStatus: http.StatusOK,
+			wantBody: `{"results": [
+				{"identifier": "192.0.2.1", "type": "IP", "valid": true},
+				{"identifier": "192.0.2.1", "type": "Hostnme", "valid": false, "error": "unknown type: Hostnme"}
+			]}`,
+		},
+		{
+			name:       "invalid body",
+			method:     http.MethodPost,
+			path:       "/detect",
+			body:       `{"identifier": "192.0.2.1"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error": "invalid request body: json: unknown field \"identifier\""}`,
+		},
+		{
+			name:       "body too large",
+			opts:       Options{MaxBodyBytes: 16},
+			method:     http.MethodPost,
+			path:       "/detect",
+			body:       `{"identifiers": ["192.0.2.1"]}`,
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   `{"error": "request body too large: limit is 16 bytes"}`,
+		},
+		{
+			name:       "too many identifiers",
+			opts:       Options{MaxIdentifiers: 1},
+			method:     http.MethodPost,
+			path:       "/validate",
+			body:       `{"assets": [{"identifier": "192.0.2.1", "type": "IP"}, {"identifier": "192.0.2.2", "type": "IP"}]}`,
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   `{"error": "too many identifiers: limit is 1"}`,
+		},
+		{
+			name:       "timeout",
+			opts:       Options{Timeout: 10 * time.Millisecond},
+			method:     http.MethodPost,
+			path:       "/detect",
+			body:       `{"identifiers": ["slow.invalid"]}`,
+			wantStatus: http.StatusGatewayTimeout,
+			wantBody:   `{"error": "request not processed: context deadline exceeded"}`,
+		},
+	}
+
+	if err := types.Register(types.AssetTypeSpec{
+		Name:     "Slow",
+		Priority: 1,
+		Validate: func(identifier string) error {
+			if identifier == "slow.invalid" {
+				time.Sleep(100 * time.Millisecond)
+			}
+			return errors.New("not slow")
+		},
+	}); err != nil {
+		t.Fatalf("could not register asset type: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := types.Unregister("Slow"); err != nil {
+			t.Errorf("could not unregister asset type: %v", err)
+		}
+	})
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Detect.Offline = true
+			h := NewHandler(tt.opts)
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("unexpected status: got %v, want %v", rec.Code, tt.wantStatus)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("unexpected content type: %v", ct)
+			}
+
+			var got, want any
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("invalid response body %q: %v", rec.Body, err)
+			}
+			if err := json.Unmarshal([]byte(tt.wantBody), &want); err != nil {
+				t.Fatalf("invalid expected body: %v", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("body mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestHandlerTypes(t *testing.T) {
+	h := NewHandler(Options{})
+
+	req := httptest.NewRequest(http.MethodGet, "/types", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %v", rec.Code)
+	}
+	var resp TypesResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid response body: %v", err)
+	}
+
+	var names []types.AssetType
+	for _, at := range resp.Types {
+		names = append(names, at.Name)
+	}
+	for _, spec := range types.AllAssetTypes() {
+		if !containsAssetType(names, spec.Name) {
+			t.Errorf("missing asset type: %v", spec.Name)
+		}
+	}
+}
+
+func TestHandlerOpenAPI(t *testing.T) {
+	h := NewHandler(Options{})
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %v", rec.Code)
+	}
+	var doc struct {
+		OpenAPI string         `json:"openapi"`
+		Paths   map[string]any `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid OpenAPI document: %v", err)
+	}
+	for _, path := range []string{"/detect", "/validate", "/types"} {
+		if _, ok := doc.Paths[path]; !ok {
+			t.Errorf("missing path in OpenAPI document: %v", path)
+		}
+	}
+}
+
+func TestHandlerMethodNotAllowed(t *testing.T) {
+	h := NewHandler(Options{})
+
+	req := httptest.NewRequest(http.MethodGet, "/detect", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("unexpected status: got %v, want %v", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func containsAssetType(ts []types.AssetType, t types.AssetType) bool {
+	for _, x := range ts {
+		if x == t {
+			return true
+		}
+	}
+	return false
+}
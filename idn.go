@@ -0,0 +1,165 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/idna"
+)
+
+// idnaProfile converts hostnames following IDNA2008 with the UTS-46
+// non-transitional mapping, so "ß" is kept instead of being replaced
+// by "ss".
+var idnaProfile = idna.New(
+	idna.MapForLookup(),
+	idna.BidiRule(),
+	idna.Transitional(false),
+)
+
+// idnaLookupProfile is like idnaProfile but allows underscores, which
+// are not valid in hostnames but are common in DNS names, e.g.:
+// _dmarc.example.com. It is used for names that are only looked up.
+var idnaLookupProfile = idna.New(
+	idna.MapForLookup(),
+	idna.BidiRule(),
+	idna.Transitional(false),
+	idna.StrictDomainName(false),
+)
+
+// NormalizedHostname contains the normalized forms of an
+// internationalized hostname.
+type NormalizedHostname struct {
+	// ASCII is the hostname with every label in A-label form, e.g.:
+	// xn--mnchen-3ya.de.
+	ASCII string
+	// Unicode is the hostname with every label in U-label form, e.g.:
+	// münchen.de.
+	Unicode string
+	// MixedScript reports whether any label of the hostname mixes
+	// characters of different scripts. See [IsHomograph].
+	MixedScript bool
+}
+
+// NormalizeHostname converts the provided hostname, either in A-label
+// or U-label form, to both forms according to IDNA2008 and UTS-46.
+// Uppercase characters are mapped to lowercase and the trailing dot,
+// if any, is removed.
+func NormalizeHostname(name string) (NormalizedHostname, error) {
+	ascii, err := toASCIIHostname(name)
+	if err != nil {
+		return NormalizedHostname{}, err
+	}
+
+	u, err := idnaProfile.ToUnicode(ascii)
+	if err != nil {
+		return NormalizedHostname{}, fmt.Errorf("invalid hostname %q: %w", name, err)
+	}
+
+	return NormalizedHostname{
+		ASCII:       ascii,
+		Unicode:     u,
+		MixedScript: isMixedScript(u),
+	}, nil
+}
+
+// IsHomograph returns true if any label of the target mixes characters
+// of different scripts, like the Cyrillic "а" in "pаypal.com", which
+// is a common technique to impersonate well known names. Labels
+// combining Latin with the scripts used together in Chinese, Japanese
+// and Korean are not considered mixed.
+func IsHomograph(target string) bool {
+	n, err := NormalizeHostname(target)
+	if err != nil {
+		return false
+	}
+	return n.MixedScript
+}
+
+// toASCIIHostname converts name to its A-label form, lowercased and
+// without trailing dot.
+func toASCIIHostname(name string) (string, error) {
+	ascii, err := idnaProfile.ToASCII(strings.TrimSuffix(name, "."))
+	if err != nil {
+		return "", fmt.Errorf("invalid hostname %q: %w", name, err)
+	}
+	return ascii, nil
+}
+
+// toASCIILookupName is like [toASCIIHostname] but allows underscores
+// in labels. It must be used to convert names before querying DNS.
+func toASCIILookupName(name string) (string, error) {
+	ascii, err := idnaLookupProfile.ToASCII(strings.TrimSuffix(name, "."))
+	if err != nil {
+		return "", fmt.Errorf("invalid hostname %q: %w", name, err)
+	}
+	return ascii, nil
+}
+
+// cjkScripts contains the sets of scripts that are legitimately mixed
+// in a single label, according to the "Highly Restrictive" level of
+// Unicode Technical Standard #39.
+var cjkScripts = []map[string]bool{
+	{"Latin": true, "Han": true, "Hiragana": true, "Katakana": true},
+	{"Latin": true, "Han": true, "Bopomofo": true},
+	{"Latin": true, "Han": true, "Hangul": true},
+}
+
+// isMixedScript returns true if any label of name contains letters of
+// more than one script, ignoring the Common and Inherited scripts.
+func isMixedScript(name string) bool {
+	for _, label := range strings.Split(name, ".") {
+		scripts := make(map[string]bool)
+		for _, r := range label {
+			if s := runeScript(r); s != "" {
+				scripts[s] = true
+			}
+		}
+		if len(scripts) > 1 && !allowedScripts(scripts) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedScripts returns true if scripts is a subset of any of the
+// allowed script combinations.
+func allowedScripts(scripts map[string]bool) bool {
+	for _, allowed := range cjkScripts {
+		ok := true
+		for s := range scripts {
+			if !allowed[s] {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// runeScript returns the name of the script of r. It returns an empty
+// string for characters in the Common or Inherited scripts.
+func runeScript(r rune) string {
+	if r < unicode.MaxASCII {
+		if unicode.IsLetter(r) {
+			return "Latin"
+		}
+		return ""
+	}
+	for name, table := range unicode.Scripts {
+		if name == "Common" || name == "Inherited" {
+			continue
+		}
+		if unicode.Is(table, r) {
+			return name
+		}
+	}
+	return ""
+}
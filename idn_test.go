@@ -0,0 +1,134 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNormalizeHostname(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		want    NormalizedHostname
+		wantErr bool
+	}{
+		{
+			name:   "ASCII",
+			target: "www.adevinta.com",
+			want: NormalizedHostname{
+				ASCII:   "www.adevinta.com",
+				Unicode: "www.adevinta.com",
+			},
+		},
+		{
+			name:   "U-label",
+			target: "münchen.de",
+			want: NormalizedHostname{
+				ASCII:   "xn--mnchen-3ya.de",
+				Unicode: "münchen.de",
+			},
+		},
+		{
+			name:   "A-label",
+			target: "xn--mnchen-3ya.de",
+			want: NormalizedHostname{
+				ASCII:   "xn--mnchen-3ya.de",
+				Unicode: "münchen.de",
+			},
+		},
+		{
+			name:   "Uppercase and trailing dot",
+			target: "MÜNCHEN.De.",
+			want: NormalizedHostname{
+				ASCII:   "xn--mnchen-3ya.de",
+				Unicode: "münchen.de",
+			},
+		},
+		{
+			name:   "Non-transitional mapping",
+			target: "straße.de",
+			want: NormalizedHostname{
+				ASCII:   "xn--strae-oqa.de",
+				Unicode: "straße.de",
+			},
+		},
+		{
+			name:   "Mixed script",
+			target: "pаypal.com",
+			want: NormalizedHostname{
+				ASCII:       "xn--pypal-4ve.com",
+				Unicode:     "pаypal.com",
+				MixedScript: true,
+			},
+		},
+		{
+			name:    "Invalid character",
+			target:  "a b.com",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeHostname(tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("hostname mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestIsHomograph(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		want   bool
+	}{
+		{
+			name:   "Latin",
+			target: "paypal.com",
+			want:   false,
+		},
+		{
+			name:   "Cyrillic in Latin label",
+			target: "pаypal.com",
+			want:   true,
+		},
+		{
+			name:   "Cyrillic in Latin label A-label",
+			target: "xn--pypal-4ve.com",
+			want:   true,
+		},
+		{
+			name:   "Cyrillic",
+			target: "пример.рф",
+			want:   false,
+		},
+		{
+			name:   "Japanese and Latin",
+			target: "日本語カタカナabc.jp",
+			want:   false,
+		},
+		{
+			name:   "Different scripts in different labels",
+			target: "пример.com",
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsHomograph(tt.target); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// Transport is the transport protocol of a network service.
+type Transport string
+
+// Supported transport protocols.
+const (
+	AnyTransport Transport = ""
+	TCP          Transport = "tcp"
+	UDP          Transport = "udp"
+	SCTP         Transport = "sctp"
+)
+
+// NetworkServiceAddress is a parsed network service address.
+type NetworkServiceAddress struct {
+	// Host is the lowercased hostname, in A-label form, or the IP
+	// address of the service, without brackets.
+	Host string
+	// Port is the port of the service.
+	Port int
+	// Transport is the transport protocol. It is AnyTransport if the
+	// address does not specify one.
+	Transport Transport
+}
+
+// ParseNetworkService parses the address of a single network service
+// with the format "host:port" or "host:port/transport", e.g.
+// "db.internal:5432", "10.0.0.4:22/tcp" or "[2001:db8::1]:53/udp".
+//
+// The host can be an IP address, where IPv6 addresses must be
+// enclosed in brackets, or a hostname with at least two labels or
+// "localhost". The port must be in the range
+// 1-65535 and the transport, if present, must be tcp, udp or sctp.
+// No network access is performed.
+func ParseNetworkService(target string) (NetworkServiceAddress, error) {
+	hostport, transport, hasTransport := strings.Cut(target, "/")
+
+	var addr NetworkServiceAddress
+	if hasTransport {
+		switch t := Transport(strings.ToLower(transport)); t {
+		case TCP, UDP, SCTP:
+			addr.Transport = t
+		default:
+			return NetworkServiceAddress{}, fmt.Errorf("unsupported transport: %q", transport)
+		}
+	}
+
+	host, port, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return NetworkServiceAddress{}, fmt.Errorf("invalid address: %w", err)
+	}
+
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return NetworkServiceAddress{}, fmt.Errorf("invalid port: %q", port)
+	}
+	addr.Port = n
+
+	bracketed := strings.HasPrefix(hostport, "[")
+	if ip, err := netip.ParseAddr(host); err == nil {
+		if bracketed != ip.Is6() {
+			return NetworkServiceAddress{}, fmt.Errorf("invalid host: %v", host)
+		}
+		addr.Host = ip.String()
+		return addr, nil
+	}
+	if bracketed {
+		return NetworkServiceAddress{}, fmt.Errorf("invalid host: %v", host)
+	}
+
+	name, err := toASCIIHostname(host)
+	if err != nil {
+		return NetworkServiceAddress{}, fmt.Errorf("invalid host: %w", err)
+	}
+	if err := ValidateHostname(name, HostnameOptions{}); err != nil {
+		return NetworkServiceAddress{}, fmt.Errorf("invalid host: %w", err)
+	}
+	// Single label hosts, except localhost, are not accepted, so
+	// Docker Hub references like "alpine:3" are not services.
+	if !strings.Contains(name, ".") && name != "localhost" {
+		return NetworkServiceAddress{}, fmt.Errorf("invalid host: single label hostname: %v", name)
+	}
+	addr.Host = name
+	return addr, nil
+}
+
+// IsNetworkService returns true if the target is the address of a
+// network service. See [ParseNetworkService].
+func IsNetworkService(target string) bool {
+	_, err := ParseNetworkService(target)
+	return err == nil
+}
+
+// String returns the address with the format "host:port" or
+// "host:port/transport".
+func (a NetworkServiceAddress) String() string {
+	s := net.JoinHostPort(a.Host, strconv.Itoa(a.Port))
+	if a.Transport != AnyTransport {
+		s += "/" + string(a.Transport)
+	}
+	return s
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseNetworkService(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		want       NetworkServiceAddress
+		wantString string
+		wantErr    bool
+	}{
+		{
+			name:       "hostname",
+			target:     "db.internal:5432",
+			want:       NetworkServiceAddress{Host: "db.internal", Port: 5432},
+			wantString: "db.internal:5432",
+		},
+		{
+			name:       "IPv4 with transport",
+			target:     "10.0.0.4:22/TCP",
+			want:       NetworkServiceAddress{Host: "10.0.0.4", Port: 22, Transport: TCP},
+			wantString: "10.0.0.4:22/tcp",
+		},
+		{
+			name:       "IPv6 with transport",
+			target:     "[2001:DB8::1]:53/udp",
+			want:       NetworkServiceAddress{Host: "2001:db8::1", Port: 53, Transport: UDP},
+			wantString: "[2001:db8::1]:53/udp",
+		},
+		{
+			name:       "internationalized hostname",
+			target:     "München.de:443/sctp",
+			want:       NetworkServiceAddress{Host: "xn--mnchen-3ya.de", Port: 443, Transport: SCTP},
+			wantString: "xn--mnchen-3ya.de:443/sctp",
+		},
+		{
+			name:    "missing port",
+			target:  "db.internal",
+			wantErr: true,
+		},
+		{
+			name:    "port out of range",
+			target:  "db.internal:65536",
+			wantErr: true,
+		},
+		{
+			name:    "port zero",
+			target:  "10.0.0.4:0",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported transport",
+			target:  "10.0.0.4:22/icmp",
+			wantErr: true,
+		},
+		{
+			name:    "Docker image",
+			target:  "localhost:5500/library/debian",
+			wantErr: true,
+		},
+		{
+			name:       "localhost",
+			target:     "localhost:5432",
+			want:       NetworkServiceAddress{Host: "localhost", Port: 5432},
+			wantString: "localhost:5432",
+		},
+		{
+			name:    "Docker Hub image",
+			target:  "alpine:3",
+			wantErr: true,
+		},
+		{
+			name:    "single label hostname",
+			target:  "db:5432",
+			wantErr: true,
+		},
+		{
+			name:    "unbracketed IPv6",
+			target:  "2001:db8::1:53",
+			wantErr: true,
+		},
+		{
+			name:    "bracketed IPv4",
+			target:  "[10.0.0.4]:22",
+			wantErr: true,
+		},
+		{
+			name:    "invalid hostname",
+			target:  "db_internal:5432",
+			wantErr: true,
+		},
+		{
+			name:    "URL",
+			target:  "https://www.example.com:443",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseNetworkService(tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("address mismatch (-want +got):\n%v", diff)
+			}
+			if err != nil {
+				return
+			}
+			if s := got.String(); s != tt.wantString {
+				t.Errorf("got string %v, want %v", s, tt.wantString)
+			}
+		})
+	}
+}
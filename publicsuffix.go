@@ -0,0 +1,196 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/net/idna"
+)
+
+// ErrPublicSuffix is returned when an identifier is a public suffix,
+// like "com" or "co.uk", and therefore cannot be owned.
+var ErrPublicSuffix = errors.New("public suffix")
+
+//go:embed data/public_suffix_list.dat
+var embeddedPublicSuffixList string
+
+var (
+	// pslOnce guards the lazy parsing of the embedded public suffix
+	// list.
+	pslOnce sync.Once
+
+	// psl is the public suffix list used by the package functions.
+	psl atomic.Pointer[publicSuffixList]
+)
+
+// publicSuffixList is a parsed public suffix list. Its rules are
+// stored in A-label form, with the wildcard ("*.") and exception
+// ("!") prefixes of the original list.
+type publicSuffixList struct {
+	rules map[string]bool
+}
+
+// parsePublicSuffixList parses a public suffix list in the format
+// described in https://publicsuffix.org/list/.
+func parsePublicSuffixList(r io.Reader) (*publicSuffixList, error) {
+	l := &publicSuffixList{rules: make(map[string]bool)}
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+		// Rules end at the first whitespace.
+		line, _, _ = strings.Cut(line, " ")
+
+		var prefix string
+		switch {
+		case strings.HasPrefix(line, "!"):
+			prefix, line = "!", line[1:]
+		case strings.HasPrefix(line, "*."):
+			prefix, line = "*.", line[2:]
+		}
+
+		rule, err := idna.ToASCII(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %q: %w", line, err)
+		}
+		l.rules[prefix+strings.ToLower(rule)] = true
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("read public suffix list: %w", err)
+	}
+
+	if len(l.rules) == 0 {
+		return nil, errors.New("empty public suffix list")
+	}
+	return l, nil
+}
+
+// publicSuffix returns the public suffix of name. The returned bool
+// reports whether the suffix matches an explicit rule of the list, as
+// opposed to the implicit "*" rule that applies to unknown top level
+// domains.
+func (l *publicSuffixList) publicSuffix(name string) (string, bool) {
+	labels := strings.Split(name, ".")
+	for i := range labels {
+		suffix := strings.Join(labels[i:], ".")
+		if l.rules["!"+suffix] {
+			return strings.Join(labels[i+1:], "."), true
+		}
+		if l.rules[suffix] {
+			return suffix, true
+		}
+		if i+1 < len(labels) && l.rules["*."+strings.Join(labels[i+1:], ".")] {
+			return suffix, true
+		}
+	}
+	return labels[len(labels)-1], false
+}
+
+// currentPublicSuffixList returns the public suffix list used by the
+// package functions, parsing the embedded one if it has not been
+// replaced.
+func currentPublicSuffixList() *publicSuffixList {
+	pslOnce.Do(func() {
+		l, err := parsePublicSuffixList(strings.NewReader(embeddedPublicSuffixList))
+		if err != nil {
+			panic(fmt.Sprintf("invalid embedded public suffix list: %v", err))
+		}
+		psl.CompareAndSwap(nil, l)
+	})
+	return psl.Load()
+}
+
+// UpdatePublicSuffixList replaces the embedded public suffix list
+// with the one stored in the provided file. The file must follow the
+// format described in https://publicsuffix.org/list/.
+func UpdatePublicSuffixList(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open public suffix list: %w", err)
+	}
+	defer f.Close()
+
+	l, err := parsePublicSuffixList(f)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the embedded list does not overwrite the new one.
+	pslOnce.Do(func() {})
+	psl.Store(l)
+	return nil
+}
+
+// normalizeDomainName converts name to its A-label form, lowercased
+// and without trailing dot.
+func normalizeDomainName(name string) (string, error) {
+	name, err := toASCIIHostname(name)
+	if err != nil {
+		return "", err
+	}
+	if name == "" || strings.HasPrefix(name, ".") || strings.Contains(name, "..") {
+		return "", fmt.Errorf("invalid domain name: %q", name)
+	}
+	return name, nil
+}
+
+// PublicSuffix returns the public suffix of the provided hostname
+// according to the public suffix list. For instance, the public
+// suffix of "api.eu.example.co.uk" is "co.uk". Unknown top level
+// domains are considered public suffixes. The returned suffix is in
+// A-label form.
+func PublicSuffix(name string) (string, error) {
+	name, err := normalizeDomainName(name)
+	if err != nil {
+		return "", err
+	}
+	suffix, _ := currentPublicSuffixList().publicSuffix(name)
+	return suffix, nil
+}
+
+// RegistrableDomain returns the registrable domain (eTLD+1) of the
+// provided hostname according to the public suffix list. For
+// instance, the registrable domain of "api.eu.example.co.uk" is
+// "example.co.uk". It returns error if the hostname is a public
+// suffix. The returned domain is in A-label form.
+func RegistrableDomain(name string) (string, error) {
+	name, err := normalizeDomainName(name)
+	if err != nil {
+		return "", err
+	}
+
+	suffix, _ := currentPublicSuffixList().publicSuffix(name)
+	if suffix == name {
+		return "", fmt.Errorf("%w: %v", ErrPublicSuffix, name)
+	}
+
+	rest := strings.TrimSuffix(name, "."+suffix)
+	return rest[strings.LastIndex(rest, ".")+1:] + "." + suffix, nil
+}
+
+// IsPublicSuffix returns true if the target is listed in the public
+// suffix list. For instance, "com", "co.uk" and "github.io" are public
+// suffixes. Unlike [PublicSuffix], unknown top level domains are not
+// considered public suffixes.
+func IsPublicSuffix(target string) bool {
+	name, err := normalizeDomainName(target)
+	if err != nil {
+		return false
+	}
+	suffix, explicit := currentPublicSuffixList().publicSuffix(name)
+	return explicit && suffix == name
+}
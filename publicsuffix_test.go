@@ -0,0 +1,205 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPublicSuffix(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "ICANN suffix",
+			target: "api.eu.example.co.uk",
+			want:   "co.uk",
+		},
+		{
+			name:   "Private suffix",
+			target: "adevinta.github.io",
+			want:   "github.io",
+		},
+		{
+			name:   "Wildcard rule",
+			target: "www.example.ck",
+			want:   "example.ck",
+		},
+		{
+			name:   "Exception rule",
+			target: "www.ck",
+			want:   "ck",
+		},
+		{
+			name:   "Unknown TLD",
+			target: "example.unknowntld",
+			want:   "unknowntld",
+		},
+		{
+			name:   "Trailing dot and uppercase",
+			target: "WWW.Example.COM.",
+			want:   "com",
+		},
+		{
+			name:    "Empty label",
+			target:  "foo..com",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PublicSuffix(tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistrableDomain(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "ICANN suffix",
+			target: "api.eu.example.co.uk",
+			want:   "example.co.uk",
+		},
+		{
+			name:   "Registrable domain",
+			target: "adevinta.com",
+			want:   "adevinta.com",
+		},
+		{
+			name:   "Private suffix",
+			target: "vulcan.adevinta.github.io",
+			want:   "adevinta.github.io",
+		},
+		{
+			name:   "Exception rule",
+			target: "www.city.kawasaki.jp",
+			want:   "city.kawasaki.jp",
+		},
+		{
+			name:   "Internationalized suffix",
+			target: "www.example.公司.cn",
+			want:   "example.xn--55qx5d.cn",
+		},
+		{
+			name:    "Public suffix",
+			target:  "co.uk",
+			wantErr: ErrPublicSuffix,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RegistrableDomain(tt.target)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPublicSuffix(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		want   bool
+	}{
+		{
+			name:   "TLD",
+			target: "com",
+			want:   true,
+		},
+		{
+			name:   "Second level suffix",
+			target: "co.uk",
+			want:   true,
+		},
+		{
+			name:   "Wildcard rule",
+			target: "example.ck",
+			want:   true,
+		},
+		{
+			name:   "Exception rule",
+			target: "www.ck",
+			want:   false,
+		},
+		{
+			name:   "Domain",
+			target: "adevinta.com",
+			want:   false,
+		},
+		{
+			name:   "Unknown TLD",
+			target: "localhost",
+			want:   false,
+		},
+		{
+			name:   "Garbage",
+			target: "31337",
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsPublicSuffix(tt.target); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdatePublicSuffixList(t *testing.T) {
+	prev := currentPublicSuffixList()
+	t.Cleanup(func() { psl.Store(prev) })
+
+	path := filepath.Join(t.TempDir(), "public_suffix_list.dat")
+	list := "// Test list.\ncom\nexample.com\n"
+	if err := os.WriteFile(path, []byte(list), 0o644); err != nil {
+		t.Fatalf("write list: %v", err)
+	}
+
+	if err := UpdatePublicSuffixList(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !IsPublicSuffix("example.com") {
+		t.Errorf("example.com is not a public suffix")
+	}
+	if IsPublicSuffix("co.uk") {
+		t.Errorf("co.uk is a public suffix")
+	}
+	if got, _ := RegistrableDomain("www.example.com"); got != "www.example.com" {
+		t.Errorf("unexpected registrable domain: %v", got)
+	}
+}
+
+func TestDetectAssetTypes_publicSuffix(t *testing.T) {
+	_, err := DetectAssetTypes("co.uk")
+	if !errors.Is(err, ErrPublicSuffix) {
+		t.Errorf("got error %v, want error %v", err, ErrPublicSuffix)
+	}
+}
@@ -0,0 +1,374 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"sort"
+	"sync"
+)
+
+// AssetTypeSpec describes an asset type known by the package.
+type AssetTypeSpec struct {
+	// Name is the asset type.
+	Name AssetType
+
+	// Validate returns nil if the identifier is a valid asset of this
+	// type, or an error describing why it is not.
+	Validate func(identifier string) error
+
+	// Priority determines when [DetectAssetTypes] tries the asset
+	// type, if it is not a built-in one. Types with a positive
+	// priority are tried before the built-in types and the rest are
+	// tried only when no built-in type matches. Higher priorities are
+	// tried first.
+	Priority int
+
+	// Normalize returns the canonical form of an identifier of this
+	// type. It is optional.
+	Normalize func(identifier string) (string, error)
+
+	// DisplayName is the human-readable name of the asset type.
+	DisplayName string
+
+	// Description describes the asset type.
+	Description string
+
+	// Examples contains example identifiers of the asset type.
+	Examples []string
+
+	// RequiresNetwork reports whether validating or detecting the
+	// asset type requires network access, like DNS queries.
+	RequiresNetwork bool
+
+	// NetworkReachable reports whether assets of this type can be
+	// reached through the network, like hosts or web applications.
+	NetworkReachable bool
+
+	builtin bool
+}
+
+var (
+	// registryMu guards registry.
+	registryMu sync.RWMutex
+
+	// registry contains the known asset types in registration order.
+	registry = builtinAssetTypes()
+)
+
+// builtinAssetTypes returns the specs of the built-in asset types.
+func builtinAssetTypes() []AssetTypeSpec {
+	specs := []AssetTypeSpec{
+		{
+			Name:        AWSAccount,
+			DisplayName: "AWS account",
+			Description: "An Amazon Web Services account, identified by the ARN of its root user.",
+			Examples:    []string{"arn:aws:iam::123456789012:root"},
+			Validate:    checkerValidator(IsAWSAccount, "not an AWS account"),
+		},
+		{
+			Name:        DockerImage,
+			DisplayName: "Docker image",
+			Description: "A container image stored in a registry. The registry must be specified, while the tag is optional.",
+			Examples:    []string{"registry-1.docker.io/library/postgres:latest", "ghcr.io/puppeteer/puppeteer"},
+			Validate:    checkerValidator(IsDockerImage, "not a Docker image"),
+		},
+		{
+			Name:        GitRepository,
+			DisplayName: "Git repository",
+			Description: "A Git repository, identified by its clone URL.",
+			Examples:    []string{"https://github.com/adevinta/vulcan-types.git", "git@github.com:adevinta/vulcan-types.git"},
+			Validate:    checkerValidator(IsGitRepository, "not a Git repository"),
+		},
+		{
+			Name:             IP,
+			DisplayName:      "IP address",
+			Description:      "A single IPv4 or IPv6 address.",
+			Examples:         []string{"192.0.2.1", "2001:db8::1"},
+			NetworkReachable: true,
+			Validate: checkerValidator(func(target string) bool {
+				return IsIP(target) || IsHost(target)
+			}, "not an IP"),
+			Normalize: normalizeIP,
+		},
+		{
+			Name:             IPRange,
+			DisplayName:      "IP range",
+			Description:      "A range of IPv4 or IPv6 addresses in CIDR notation.",
+			Examples:         []string{"192.0.2.0/24", "2001:db8::/64"},
+			NetworkReachable: true,
+			Validate: checkerValidator(func(target string) bool {
+				return IsCIDR(target) && !IsHost(target)
+			}, "not an IP range"),
+			Normalize: normalizeCIDR,
+		},
+		{
+			Name:            DomainName,
+			DisplayName:     "Domain name",
+			Description:     "A DNS zone, that is, a name with a SOA record.",
+			Examples:        []string{"example.com"},
+			RequiresNetwork: true,
+			Validate:        domainNameValidator(DetectOptions{}),
+			Normalize:       toASCIIHostname,
+		},
+		{
+			Name:             Hostname,
+			DisplayName:      "Hostname",
+			Description:      "A name that resolves to one or more IP addresses.",
+			Examples:         []string{"www.example.com"},
+			RequiresNetwork:  true,
+			NetworkReachable: true,
+			Validate:         hostnameValidator(DetectOptions{}),
+			Normalize:        toASCIIHostname,
+		},
+		{
+			Name:             WebAddress,
+			DisplayName:      "Web address",
+			Description:      "An HTTP or HTTPS URL whose host is an IP address or a hostname that can be resolved.",
+			Examples:         []string{"https://www.example.com/"},
+			RequiresNetwork:  true,
+			NetworkReachable: true,
+			Validate:         webAddressValidator(DetectOptions{}),
+			Normalize: func(target string) (string, error) {
+				return CanonicalizeWebAddress(target, CanonicalizeOptions{})
+			},
+		},
+		{
+			Name:        DomainPattern,
+			DisplayName: "Domain pattern",
+			Description: "A wildcard matching the hostnames one level below a domain.",
+			Examples:    []string{"*.example.com"},
+			Validate: func(target string) error {
+				_, err := ParseDomainPattern(target)
+				return err
+			},
+			Normalize: func(target string) (string, error) {
+				p, err := ParseDomainPattern(target)
+				if err != nil {
+					return "", err
+				}
+				return p.String(), nil
+			},
+		},
+		{
+			Name:             ServiceURL,
+			DisplayName:      "Service URL",
+			Description:      "A URL of a network service that is not a web application, like a WebSocket endpoint, an FTP, SFTP or LDAP server, or a PostgreSQL, MySQL or MongoDB database. Credentials are removed when normalized.",
+			Examples:         []string{"wss://ws.example.com/feed", "postgres://db.example.com:5432/app"},
+			NetworkReachable: true,
+			Validate: func(target string) error {
+				_, err := ParseServiceURL(target)
+				return err
+			},
+			Normalize: StripCredentials,
+		},
+		{
+			Name:             NetworkService,
+			DisplayName:      "Network service",
+			Description:      "A single service exposed by a host, identified by the host, the port and, optionally, the transport protocol.",
+			Examples:         []string{"db.example.com:5432", "192.0.2.4:22/tcp", "[2001:db8::1]:53/udp"},
+			NetworkReachable: true,
+			Validate: func(target string) error {
+				_, err := ParseNetworkService(target)
+				return err
+			},
+			Normalize: func(target string) (string, error) {
+				addr, err := ParseNetworkService(target)
+				if err != nil {
+					return "", err
+				}
+				return addr.String(), nil
+			},
+		},
+	}
+	for i := range specs {
+		specs[i].builtin = true
+	}
+	return specs
+}
+
+// checkerValidator returns a validation function based on a checker
+// function like [IsIP].
+func checkerValidator(check func(string) bool, msg string) func(string) error {
+	return func(target string) error {
+		if !check(target) {
+			return errors.New(msg)
+		}
+		return nil
+	}
+}
+
+// hostnameValidator returns the validation function of the Hostname
+// asset type for the provided options.
+func hostnameValidator(opts DetectOptions) func(string) error {
+	msg := "not a resolvable hostname"
+	if opts.Offline {
+		msg = "not a valid hostname"
+	}
+	return func(target string) error {
+		// Public suffixes are rejected like in DetectAssetTypes.
+		if IsPublicSuffix(target) {
+			return fmt.Errorf("%w: %v", ErrPublicSuffix, target)
+		}
+		if !opts.isHostname(target) {
+			return errors.New(msg)
+		}
+		return nil
+	}
+}
+
+// domainNameValidator returns the validation function of the
+// DomainName asset type for the provided options.
+func domainNameValidator(opts DetectOptions) func(string) error {
+	return func(target string) error {
+		// Public suffixes are rejected like in DetectAssetTypes.
+		if IsPublicSuffix(target) {
+			return fmt.Errorf("%w: %v", ErrPublicSuffix, target)
+		}
+		ok, err := opts.isDomainName(target)
+		if err != nil {
+			return fmt.Errorf("%w: cannot guess if the asset is a domain: %w", ErrDNSQuery, err)
+		}
+		if !ok {
+			return errors.New("not a domain name")
+		}
+		return nil
+	}
+}
+
+// webAddressValidator returns the validation function of the
+// WebAddress asset type for the provided options.
+func webAddressValidator(opts DetectOptions) func(string) error {
+	return func(target string) error {
+		status, err := WebAddressHostStatus(target, opts)
+		if err != nil {
+			return errors.New("not a web address")
+		}
+		if status == HostUnresolved && !opts.AcceptUnresolvedWebAddresses {
+			return errors.New("not a resolvable web address")
+		}
+		return nil
+	}
+}
+
+// normalizeIP returns the canonical form of an IP, removing the /32
+// mask if present.
+func normalizeIP(target string) (string, error) {
+	if IsHost(target) {
+		target = target[:len(target)-len("/32")]
+	}
+	ip := net.ParseIP(target)
+	if ip == nil {
+		return "", fmt.Errorf("invalid IP: %v", target)
+	}
+	return ip.String(), nil
+}
+
+// normalizeCIDR returns the canonical form of a CIDR.
+func normalizeCIDR(target string) (string, error) {
+	p, err := netip.ParsePrefix(target)
+	if err != nil {
+		return "", fmt.Errorf("invalid CIDR: %w", err)
+	}
+	return p.String(), nil
+}
+
+// Register registers a custom asset type, so it is recognized by
+// [Parse], [AssetType.IsValid], [AllAssetTypes] and
+// [DetectAssetTypes]. The metadata fields of the spec are optional.
+// It returns error if the spec has no name or validation function, or
+// if the asset type is already registered.
+func Register(spec AssetTypeSpec) error {
+	if spec.Name == "" {
+		return errors.New("missing asset type name")
+	}
+	if spec.Validate == nil {
+		return fmt.Errorf("missing validation function for type: %v", spec.Name)
+	}
+	spec.builtin = false
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for _, s := range registry {
+		if s.Name == spec.Name {
+			return fmt.Errorf("type already registered: %v", spec.Name)
+		}
+	}
+	registry = append(registry, spec)
+	return nil
+}
+
+// Unregister removes a custom asset type registered with [Register].
+// It returns error if the asset type is not registered or it is a
+// built-in one.
+func Unregister(t AssetType) error {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for i, s := range registry {
+		if s.Name != t {
+			continue
+		}
+		if s.builtin {
+			return fmt.Errorf("cannot unregister built-in type: %v", t)
+		}
+		registry = append(registry[:i:i], registry[i+1:]...)
+		return nil
+	}
+	return fmt.Errorf("type not registered: %v", t)
+}
+
+// AllAssetTypes returns the specs of the known asset types, including
+// the built-in ones, in registration order. Besides the validation
+// functions, the specs contain metadata like the human-readable name,
+// a description and example identifiers of every asset type, which
+// can be used to render onboarding forms.
+func AllAssetTypes() []AssetTypeSpec {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	specs := make([]AssetTypeSpec, len(registry))
+	copy(specs, registry)
+	return specs
+}
+
+// lookupAssetType returns the spec of the provided asset type.
+func lookupAssetType(t AssetType) (AssetTypeSpec, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for _, s := range registry {
+		if s.Name == t {
+			return s, true
+		}
+	}
+	return AssetTypeSpec{}, false
+}
+
+// detectCustomAssetType returns the first custom asset type, ordered
+// by descending priority, accepted by filter whose validation
+// function succeeds for the identifier.
+func detectCustomAssetType(identifier string, filter func(priority int) bool) (AssetType, bool) {
+	var specs []AssetTypeSpec
+	for _, s := range AllAssetTypes() {
+		if !s.builtin && filter(s.Priority) {
+			specs = append(specs, s)
+		}
+	}
+	sort.SliceStable(specs, func(i, j int) bool {
+		return specs[i].Priority > specs[j].Priority
+	})
+
+	for _, s := range specs {
+		if s.Validate(identifier) == nil {
+			return s.Name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// restoreRegistry restores the asset type registry when the test
+// finishes.
+func restoreRegistry(t *testing.T) {
+	t.Helper()
+
+	prev := AllAssetTypes()
+	t.Cleanup(func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		registry = prev
+	})
+}
+
+func TestRegister(t *testing.T) {
+	restoreRegistry(t)
+
+	const (
+		KubernetesCluster AssetType = "KubernetesCluster"
+		SalesforceOrg     AssetType = "SalesforceOrg"
+	)
+
+	err := Register(AssetTypeSpec{
+		Name: KubernetesCluster,
+		Validate: func(identifier string) error {
+			if !strings.HasPrefix(identifier, "k8s://") {
+				return errors.New("not a Kubernetes cluster")
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = Register(AssetTypeSpec{
+		Name: SalesforceOrg,
+		Validate: func(identifier string) error {
+			if !strings.HasSuffix(identifier, ".my.salesforce.com") {
+				return errors.New("not a Salesforce org")
+			}
+			return nil
+		},
+		Priority: 1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, err := Parse("KubernetesCluster"); err != nil || got != KubernetesCluster {
+		t.Errorf("unexpected parse result: %v, %v", got, err)
+	}
+	if !SalesforceOrg.IsValid() {
+		t.Errorf("%v is not valid", SalesforceOrg)
+	}
+
+	var names []AssetType
+	for _, s := range AllAssetTypes() {
+		names = append(names, s.Name)
+	}
+	wantNames := []AssetType{
+		AWSAccount, DockerImage, GitRepository, IP, IPRange, DomainName,
+		Hostname, WebAddress, DomainPattern, ServiceURL, NetworkService, KubernetesCluster, SalesforceOrg,
+	}
+	if diff := cmp.Diff(wantNames, names); diff != "" {
+		t.Errorf("asset types mismatch (-want +got):\n%v", diff)
+	}
+
+	detectTests := []struct {
+		identifier string
+		want       []AssetType
+	}{
+		{
+			identifier: "k8s://prod-cluster",
+			want:       []AssetType{KubernetesCluster},
+		},
+		{
+			identifier: "acme.my.salesforce.com",
+			want:       []AssetType{SalesforceOrg},
+		},
+		{
+			identifier: "192.0.2.1",
+			want:       []AssetType{IP},
+		},
+	}
+	for _, tt := range detectTests {
+		got, err := DetectAssetTypes(tt.identifier)
+		if err != nil {
+			t.Errorf("unexpected error detecting %v: %v", tt.identifier, err)
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("asset types mismatch for %v (-want +got):\n%v", tt.identifier, diff)
+		}
+	}
+}
+
+func TestRegister_errors(t *testing.T) {
+	restoreRegistry(t)
+
+	validate := func(string) error { return nil }
+
+	tests := []struct {
+		name string
+		spec AssetTypeSpec
+	}{
+		{
+			name: "missing name",
+			spec: AssetTypeSpec{Validate: validate},
+		},
+		{
+			name: "missing validation function",
+			spec: AssetTypeSpec{Name: "Custom"},
+		},
+		{
+			name: "built-in type",
+			spec: AssetTypeSpec{Name: Hostname, Validate: validate},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Register(tt.spec); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
+
+func TestUnregister(t *testing.T) {
+	restoreRegistry(t)
+
+	const KubernetesCluster AssetType = "KubernetesCluster"
+
+	err := Register(AssetTypeSpec{
+		Name:     KubernetesCluster,
+		Validate: func(string) error { return nil },
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := Unregister(KubernetesCluster); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if KubernetesCluster.IsValid() {
+		t.Errorf("%v is still valid", KubernetesCluster)
+	}
+	if err := Register(AssetTypeSpec{Name: KubernetesCluster, Validate: func(string) error { return nil }}); err != nil {
+		t.Errorf("could not register the type again: %v", err)
+	}
+}
+
+func TestUnregister_errors(t *testing.T) {
+	restoreRegistry(t)
+
+	tests := []struct {
+		name string
+		t    AssetType
+	}{
+		{
+			name: "not registered",
+			t:    "Custom",
+		},
+		{
+			name: "built-in type",
+			t:    Hostname,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Unregister(tt.t); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
+
+func TestAllAssetTypes_metadata(t *testing.T) {
+	for _, s := range AllAssetTypes() {
+		t.Run(string(s.Name), func(t *testing.T) {
+			if s.DisplayName == "" || s.Description == "" {
+				t.Errorf("missing display name or description")
+			}
+			if len(s.Examples) == 0 {
+				t.Fatalf("missing examples")
+			}
+			if s.RequiresNetwork {
+				return
+			}
+			for _, e := range s.Examples {
+				if err := s.Validate(e); err != nil {
+					t.Errorf("invalid example %q: %v", e, err)
+				}
+			}
+		})
+	}
+}
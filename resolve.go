@@ -0,0 +1,192 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// ErrHostNotFound is returned when a hostname does not resolve to any
+// IP address.
+var ErrHostNotFound = errors.New("host not found")
+
+// ResolvedAddress is an IP address a hostname resolves to.
+type ResolvedAddress struct {
+	// IP is the IP address.
+	IP netip.Addr
+	// TTL is the time to live of the A or AAAA record.
+	TTL time.Duration
+}
+
+// CNAMERecord is a CNAME record followed while resolving a hostname.
+type CNAMERecord struct {
+	// Name is the alias.
+	Name string
+	// Target is the canonical name the alias points to.
+	Target string
+	// TTL is the time to live of the record.
+	TTL time.Duration
+}
+
+// HostnameResolution contains the details of the resolution of a
+// hostname.
+type HostnameResolution struct {
+	// Name is the resolved hostname, in A-label form and without the
+	// trailing dot.
+	Name string
+	// Addresses contains the IPv4 and IPv6 addresses of the hostname,
+	// in the order returned by the DNS server, IPv4 first.
+	Addresses []ResolvedAddress
+	// CNAMEChain contains the CNAME records followed to reach the
+	// addresses, in order. It is empty if the hostname has A or AAAA
+	// records itself.
+	CNAMEChain []CNAMERecord
+	// Resolver is the address of the DNS server that answered.
+	Resolver string
+}
+
+// ResolveHostname queries the A and AAAA records of a hostname using
+// the DNS servers in /etc/resolv.conf, and returns its addresses with
+// their TTLs, the CNAME chain and the DNS server that answered. Unlike
+// [IsHostname], it does not read /etc/hosts.
+//
+// Internationalized hostnames are converted to their A-label form
+// before being resolved. It returns an error wrapping
+// [ErrHostNotFound] if the hostname does not resolve to any address,
+// and an error wrapping [ErrDNSQuery] if the DNS servers cannot be
+// queried.
+func ResolveHostname(ctx context.Context, name string) (HostnameResolution, error) {
+	return resolveHostname(ctx, name, "")
+}
+
+// resolveHostname is like [ResolveHostname] but queries the provided
+// DNS server. If resolver is empty, the servers in /etc/resolv.conf
+// are queried.
+func resolveHostname(ctx context.Context, name, resolver string) (HostnameResolution, error) {
+	if IsIP(name) {
+		return HostnameResolution{}, fmt.Errorf("not a hostname: %v", name)
+	}
+	ascii, err := toASCIILookupName(name)
+	if err != nil {
+		return HostnameResolution{}, fmt.Errorf("invalid hostname: %w", err)
+	}
+
+	res := HostnameResolution{Name: ascii}
+	for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+		r, server, err := dnsQuery(ctx, dns.Fqdn(ascii), qtype, resolver)
+		if err != nil {
+			return HostnameResolution{}, fmt.Errorf("%w: %w", ErrDNSQuery, err)
+		}
+		if res.Resolver == "" {
+			res.Resolver = server
+		}
+
+		chain, addrs := parseAddressAnswer(r, dns.Fqdn(ascii))
+		if len(res.CNAMEChain) == 0 {
+			res.CNAMEChain = chain
+		}
+		res.Addresses = append(res.Addresses, addrs...)
+	}
+
+	if len(res.Addresses) == 0 {
+		return res, fmt.Errorf("%w: %v", ErrHostNotFound, ascii)
+	}
+	return res, nil
+}
+
+// dnsQuery sends a query to the DNS servers in order until one of
+// them answers, and returns the response and the address of the server
+// that answered. Responses with errors other than NXDOMAIN are
+// considered failures and the next server is tried.
+func dnsQuery(ctx context.Context, fqdn string, qtype uint16, resolver string) (*dns.Msg, string, error) {
+	servers, err := dnsServers(resolver)
+	if err != nil {
+		return nil, "", err
+	}
+
+	m := &dns.Msg{}
+	m.SetQuestion(fqdn, qtype)
+	m.SetEdns0(dns.DefaultMsgSize, false)
+
+	var lastErr error
+	for _, address := range servers {
+		c := dns.Client{}
+		r, _, err := c.ExchangeContext(ctx, m, address)
+		if err == nil && r.Truncated {
+			// If UDP response was truncated then try through TCP.
+			c.Net = "tcp"
+			r, _, err = c.ExchangeContext(ctx, m, address)
+		}
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if r.Rcode != dns.RcodeSuccess && r.Rcode != dns.RcodeNameError {
+			lastErr = fmt.Errorf("%v answered %v", address, dns.RcodeToString[r.Rcode])
+			continue
+		}
+		return r, address, nil
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no DNS servers configured")
+	}
+	return nil, "", lastErr
+}
+
+// parseAddressAnswer returns the CNAME chain starting at fqdn and the
+// A and AAAA records of the last name in the chain.
+func parseAddressAnswer(r *dns.Msg, fqdn string) ([]CNAMERecord, []ResolvedAddress) {
+	var chain []CNAMERecord
+	name := fqdn
+
+	// Follow the chain, guarding against loops.
+	for i := 0; i < len(r.Answer); i++ {
+		next := ""
+		for _, rr := range r.Answer {
+			c, ok := rr.(*dns.CNAME)
+			if ok && strings.EqualFold(c.Hdr.Name, name) {
+				chain = append(chain, CNAMERecord{
+					Name:   strings.TrimSuffix(c.Hdr.Name, "."),
+					Target: strings.TrimSuffix(c.Target, "."),
+					TTL:    time.Duration(c.Hdr.Ttl) * time.Second,
+				})
+				next = c.Target
+				break
+			}
+		}
+		if next == "" {
+			break
+		}
+		name = next
+	}
+
+	var addrs []ResolvedAddress
+	for _, rr := range r.Answer {
+		if !strings.EqualFold(rr.Header().Name, name) {
+			continue
+		}
+		var ip netip.Addr
+		switch v := rr.(type) {
+		case *dns.A:
+			ip, _ = netip.AddrFromSlice(v.A.To4())
+		case *dns.AAAA:
+			ip, _ = netip.AddrFromSlice(v.AAAA)
+		default:
+			continue
+		}
+		addrs = append(addrs, ResolvedAddress{
+			IP:  ip,
+			TTL: time.Duration(rr.Header().Ttl) * time.Second,
+		})
+	}
+	return chain, addrs
+}
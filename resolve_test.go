@@ -0,0 +1,128 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestResolveHostname(t *testing.T) {
+	resolver := startTestDNSServer(t, testZone{
+		"www.example.test. 300 IN A 192.0.2.1",
+		"www.example.test. 300 IN A 192.0.2.2",
+		"www.example.test. 600 IN AAAA 2001:db8::1",
+		"alias.example.test. 60 IN CNAME cdn.example.test.",
+		"cdn.example.test. 120 IN CNAME edge.cdn.example.test.",
+		"edge.cdn.example.test. 30 IN A 192.0.2.10",
+		"txt.example.test. 60 IN TXT \"no addresses\"",
+	})
+
+	tests := []struct {
+		name        string
+		hostname    string
+		want        HostnameResolution
+		wantErr     bool
+		wantErrType error
+	}{
+		{
+			name:     "A and AAAA records",
+			hostname: "WWW.example.test.",
+			want: HostnameResolution{
+				Name: "www.example.test",
+				Addresses: []ResolvedAddress{
+					{IP: netip.MustParseAddr("192.0.2.1"), TTL: 300 * time.Second},
+					{IP: netip.MustParseAddr("192.0.2.2"), TTL: 300 * time.Second},
+					{IP: netip.MustParseAddr("2001:db8::1"), TTL: 600 * time.Second},
+				},
+				Resolver: resolver,
+			},
+		},
+		{
+			name:     "CNAME chain",
+			hostname: "alias.example.test",
+			want: HostnameResolution{
+				Name: "alias.example.test",
+				Addresses: []ResolvedAddress{
+					{IP: netip.MustParseAddr("192.0.2.10"), TTL: 30 * time.Second},
+				},
+				CNAMEChain: []CNAMERecord{
+					{Name: "alias.example.test", Target: "cdn.example.test", TTL: 60 * time.Second},
+					{Name: "cdn.example.test", Target: "edge.cdn.example.test", TTL: 120 * time.Second},
+				},
+				Resolver: resolver,
+			},
+		},
+		{
+			name:        "no addresses",
+			hostname:    "txt.example.test",
+			want:        HostnameResolution{Name: "txt.example.test", Resolver: resolver},
+			wantErr:     true,
+			wantErrType: ErrHostNotFound,
+		},
+		{
+			name:        "NXDOMAIN",
+			hostname:    "unknown.example.test",
+			want:        HostnameResolution{Name: "unknown.example.test", Resolver: resolver},
+			wantErr:     true,
+			wantErrType: ErrHostNotFound,
+		},
+		{
+			name:     "IP",
+			hostname: "192.0.2.1",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveHostname(context.Background(), tt.hostname)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErrType != nil && !errors.Is(err, tt.wantErrType) {
+				t.Errorf("got error %v, want %v", err, tt.wantErrType)
+			}
+			if diff := cmp.Diff(tt.want, got, cmp.Comparer(func(a, b netip.Addr) bool { return a == b })); diff != "" {
+				t.Errorf("resolution mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestDetectAssetTypesOnHostnameResolved(t *testing.T) {
+	resolver := startTestDNSServer(t, testZone{
+		"www.example.test. 300 IN A 192.0.2.1",
+	})
+
+	var resolutions []HostnameResolution
+	opts := DetectOptions{
+		Resolver: resolver,
+		OnHostnameResolved: func(res HostnameResolution) {
+			resolutions = append(resolutions, res)
+		},
+	}
+	got, err := DetectAssetTypesWithOptions("https://www.example.test/", opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]AssetType{Hostname, WebAddress}, got); diff != "" {
+		t.Errorf("asset types mismatch (-want +got):\n%v", diff)
+	}
+
+	want := []HostnameResolution{{
+		Name:      "www.example.test",
+		Addresses: []ResolvedAddress{{IP: netip.MustParseAddr("192.0.2.1"), TTL: 300 * time.Second}},
+		Resolver:  resolver,
+	}}
+	if diff := cmp.Diff(want, resolutions, cmp.Comparer(func(a, b netip.Addr) bool { return a == b })); diff != "" {
+		t.Errorf("resolutions mismatch (-want +got):\n%v", diff)
+	}
+}
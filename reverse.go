@@ -0,0 +1,227 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// ErrNoPTRRecord is returned when an IP address has no PTR records.
+var ErrNoPTRRecord = errors.New("no PTR records")
+
+// Default values of [ReverseLookupOptions].
+const (
+	DefaultReverseLookupRate         = 10
+	DefaultReverseLookupMaxAddresses = 256
+)
+
+// PTRName is a name returned by a PTR query.
+type PTRName struct {
+	// Name is the hostname, without the trailing dot.
+	Name string
+	// TTL is the time to live of the PTR record.
+	TTL time.Duration
+	// ForwardConfirmed reports whether the name resolves back to the
+	// IP address (forward-confirmed reverse DNS, or FCrDNS).
+	ForwardConfirmed bool
+}
+
+// ReverseDNS contains the PTR names of an IP address.
+type ReverseDNS struct {
+	// IP is the IP address.
+	IP netip.Addr
+	// Names contains the names in the PTR records of the address.
+	Names []PTRName
+	// Resolver is the address of the DNS server that answered the
+	// PTR query.
+	Resolver string
+}
+
+// ConfirmedNames returns the names that are forward-confirmed, that
+// is, the names that can be linked to the IP address with
+// confidence.
+func (r ReverseDNS) ConfirmedNames() []string {
+	var names []string
+	for _, n := range r.Names {
+		if n.ForwardConfirmed {
+			names = append(names, n.Name)
+		}
+	}
+	return names
+}
+
+// ReverseLookup queries the PTR records of an IPv4 or IPv6 address
+// using the DNS servers in /etc/resolv.conf. Every returned name is
+// resolved to check whether it points back to the address (FCrDNS).
+//
+// It returns an error wrapping [ErrNoPTRRecord] if the address has no
+// PTR records, and an error wrapping [ErrDNSQuery] if the DNS servers
+// cannot be queried for the PTR records. Names that cannot be
+// resolved are returned as not forward-confirmed.
+func ReverseLookup(ctx context.Context, ip string) (ReverseDNS, error) {
+	return reverseLookup(ctx, ip, "")
+}
+
+// reverseLookup is like [ReverseLookup] but queries the provided DNS
+// server. If resolver is empty, the servers in /etc/resolv.conf are
+// queried.
+func reverseLookup(ctx context.Context, ip, resolver string) (ReverseDNS, error) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ReverseDNS{}, fmt.Errorf("invalid IP: %w", err)
+	}
+	addr = addr.Unmap().WithZone("")
+
+	r, server, err := dnsQuery(ctx, reverseName(addr), dns.TypePTR, resolver)
+	if err != nil {
+		return ReverseDNS{}, fmt.Errorf("%w: %w", ErrDNSQuery, err)
+	}
+
+	rev := ReverseDNS{IP: addr, Resolver: server}
+	for _, rr := range r.Answer {
+		ptr, ok := rr.(*dns.PTR)
+		if !ok {
+			continue
+		}
+		name := PTRName{
+			Name: strings.TrimSuffix(ptr.Ptr, "."),
+			TTL:  time.Duration(ptr.Hdr.Ttl) * time.Second,
+		}
+
+		// Names that cannot be resolved, for whatever reason, are
+		// just not forward-confirmed.
+		if res, err := resolveHostname(ctx, name.Name, resolver); err == nil {
+			for _, a := range res.Addresses {
+				if a.IP.Unmap() == addr {
+					name.ForwardConfirmed = true
+					break
+				}
+			}
+		}
+		rev.Names = append(rev.Names, name)
+	}
+
+	if len(rev.Names) == 0 {
+		return rev, fmt.Errorf("%w: %v", ErrNoPTRRecord, addr)
+	}
+	return rev, nil
+}
+
+// reverseName returns the name used to query the PTR records of an
+// address, under in-addr.arpa for IPv4 and ip6.arpa for IPv6.
+func reverseName(addr netip.Addr) string {
+	var b strings.Builder
+	if addr.Is4() {
+		a := addr.As4()
+		for i := len(a) - 1; i >= 0; i-- {
+			b.WriteString(strconv.Itoa(int(a[i])))
+			b.WriteByte('.')
+		}
+		b.WriteString("in-addr.arpa.")
+		return b.String()
+	}
+
+	const hex = "0123456789abcdef"
+	a := addr.As16()
+	for i := len(a) - 1; i >= 0; i-- {
+		b.WriteByte(hex[a[i]&0x0f])
+		b.WriteByte('.')
+		b.WriteByte(hex[a[i]>>4])
+		b.WriteByte('.')
+	}
+	b.WriteString("ip6.arpa.")
+	return b.String()
+}
+
+// ReverseLookupOptions configures [ReverseLookupRange].
+type ReverseLookupOptions struct {
+	// Rate is the maximum number of addresses looked up per second.
+	// It defaults to DefaultReverseLookupRate.
+	Rate float64
+
+	// MaxAddresses is the maximum number of addresses in the range.
+	// It defaults to DefaultReverseLookupMaxAddresses.
+	MaxAddresses int
+
+	// Resolver is the address of the DNS server used to query the
+	// PTR records and resolve the returned names, e.g.
+	// "192.0.2.53:53". The port defaults to 53. If empty, the servers
+	// in /etc/resolv.conf are used.
+	Resolver string
+}
+
+// ReverseLookupRange calls [ReverseLookup] for every address of an IP
+// or CIDR, at the rate and using the DNS server configured in the
+// options, and returns the results of the addresses that have PTR
+// records, in address order.
+//
+// It returns error if the range has more addresses than allowed, if
+// the context is done or if a DNS query fails. In the last two cases,
+// the results obtained so far are returned along with the error.
+func ReverseLookupRange(ctx context.Context, target string, opts ReverseLookupOptions) ([]ReverseDNS, error) {
+	if opts.Rate <= 0 {
+		opts.Rate = DefaultReverseLookupRate
+	}
+	if opts.MaxAddresses <= 0 {
+		opts.MaxAddresses = DefaultReverseLookupMaxAddresses
+	}
+
+	prefix, err := parseIPRange(target)
+	if err != nil {
+		return nil, err
+	}
+	hostBits := prefix.Addr().BitLen() - prefix.Bits()
+	if hostBits >= 31 || 1<<hostBits > opts.MaxAddresses {
+		return nil, fmt.Errorf("too many addresses in %v: limit is %v", prefix, opts.MaxAddresses)
+	}
+
+	// Very high rates result in intervals shorter than the
+	// resolution of time.Duration.
+	interval := max(time.Duration(float64(time.Second)/opts.Rate), time.Nanosecond)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var results []ReverseDNS
+	for addr := prefix.Addr(); addr.IsValid() && prefix.Contains(addr); addr = addr.Next() {
+		if addr != prefix.Addr() {
+			select {
+			case <-ctx.Done():
+				return results, ctx.Err()
+			case <-ticker.C:
+			}
+		}
+
+		rev, err := reverseLookup(ctx, addr.String(), opts.Resolver)
+		if errors.Is(err, ErrNoPTRRecord) {
+			continue
+		}
+		if err != nil {
+			return results, err
+		}
+		results = append(results, rev)
+	}
+	return results, nil
+}
+
+// parseIPRange parses an IP address or a CIDR into a masked prefix.
+func parseIPRange(target string) (netip.Prefix, error) {
+	if addr, err := netip.ParseAddr(target); err == nil {
+		addr = addr.Unmap()
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+	prefix, err := netip.ParsePrefix(target)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid IP or CIDR: %v", target)
+	}
+	return prefix.Masked(), nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReverseName(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want string
+	}{
+		{ip: "192.0.2.1", want: "1.2.0.192.in-addr.arpa."},
+		{ip: "::ffff:192.0.2.1", want: "1.2.0.192.in-addr.arpa."},
+		{ip: "2001:db8::567:89ab", want: "b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			got := reverseName(netip.MustParseAddr(tt.ip).Unmap())
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReverseLookup(t *testing.T) {
+	resolver := startTestDNSServer(t, testZone{
+		"1.2.0.192.in-addr.arpa. 3600 IN PTR www.example.test.",
+		"1.2.0.192.in-addr.arpa. 3600 IN PTR spoofed.example.test.",
+		"3.2.0.192.in-addr.arpa. 3600 IN PTR xn--a.example.test.",
+		"3.2.0.192.in-addr.arpa. 3600 IN PTR www.example.test.",
+		"b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa. 600 IN PTR v6.example.test.",
+		"www.example.test. 300 IN A 192.0.2.1",
+		"www.example.test. 300 IN A 192.0.2.3",
+		"spoofed.example.test. 300 IN A 198.51.100.1",
+		"v6.example.test. 300 IN AAAA 2001:db8::567:89ab",
+	})
+
+	tests := []struct {
+		name        string
+		ip          string
+		want        ReverseDNS
+		wantErrType error
+	}{
+		{
+			name: "IPv4",
+			ip:   "192.0.2.1",
+			want: ReverseDNS{
+				IP: netip.MustParseAddr("192.0.2.1"),
+				Names: []PTRName{
+					{Name: "www.example.test", TTL: time.Hour, ForwardConfirmed: true},
+					{Name: "spoofed.example.test", TTL: time.Hour},
+				},
+				Resolver: resolver,
+			},
+		},
+		{
+			name: "unresolvable name",
+			ip:   "192.0.2.3",
+			want: ReverseDNS{
+				IP: netip.MustParseAddr("192.0.2.3"),
+				Names: []PTRName{
+					{Name: "xn--a.example.test", TTL: time.Hour},
+					{Name: "www.example.test", TTL: time.Hour, ForwardConfirmed: true},
+				},
+				Resolver: resolver,
+			},
+		},
+		{
+			name: "IPv6",
+			ip:   "2001:db8::567:89ab",
+			want: ReverseDNS{
+				IP:       netip.MustParseAddr("2001:db8::567:89ab"),
+				Names:    []PTRName{{Name: "v6.example.test", TTL: 10 * time.Minute, ForwardConfirmed: true}},
+				Resolver: resolver,
+			},
+		},
+		{
+			name: "no PTR records",
+			ip:   "192.0.2.2",
+			want: ReverseDNS{
+				IP:       netip.MustParseAddr("192.0.2.2"),
+				Resolver: resolver,
+			},
+			wantErrType: ErrNoPTRRecord,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReverseLookup(context.Background(), tt.ip)
+			if !errors.Is(err, tt.wantErrType) {
+				t.Errorf("got error %v, want %v", err, tt.wantErrType)
+			}
+			if diff := cmp.Diff(tt.want, got, cmp.Comparer(func(a, b netip.Addr) bool { return a == b })); diff != "" {
+				t.Errorf("result mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+
+	got, err := ReverseLookup(context.Background(), "192.0.2.1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"www.example.test"}, got.ConfirmedNames()); diff != "" {
+		t.Errorf("confirmed names mismatch (-want +got):\n%v", diff)
+	}
+}
+
+func TestReverseLookupRange(t *testing.T) {
+	resolver := startTestDNSServer(t, testZone{
+		"1.2.0.192.in-addr.arpa. 3600 IN PTR a.example.test.",
+		"2.2.0.192.in-addr.arpa. 3600 IN PTR xn--a.example.test.",
+		"3.2.0.192.in-addr.arpa. 3600 IN PTR c.example.test.",
+		"a.example.test. 300 IN A 192.0.2.1",
+	})
+
+	got, err := ReverseLookupRange(context.Background(), "192.0.2.0/30", ReverseLookupOptions{Rate: 1000, Resolver: resolver})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var ips []string
+	for _, r := range got {
+		ips = append(ips, r.IP.String())
+	}
+	if diff := cmp.Diff([]string{"192.0.2.1", "192.0.2.2", "192.0.2.3"}, ips); diff != "" {
+		t.Errorf("IPs mismatch (-want +got):\n%v", diff)
+	}
+
+	got, err = ReverseLookupRange(context.Background(), "192.0.2.0/30", ReverseLookupOptions{Rate: 1e12, Resolver: resolver})
+	if err != nil {
+		t.Fatalf("unexpected error with a very high rate: %v", err)
+	}
+	if len(got) != len(ips) {
+		t.Errorf("got %v results with a very high rate, want %v", len(got), len(ips))
+	}
+
+	if _, err := ReverseLookupRange(context.Background(), "192.0.2.0/23", ReverseLookupOptions{}); err == nil {
+		t.Error("expected error for a range larger than the limit")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ReverseLookupRange(ctx, "192.0.2.0/30", ReverseLookupOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+}
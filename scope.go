@@ -0,0 +1,321 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+)
+
+// awsAccountIDRegexp matches AWS account IDs.
+var awsAccountIDRegexp = regexp.MustCompile(`^[0-9]{12}$`)
+
+// ScopeRule is a rule of a [Scope]. The meaning of the value depends
+// on the type of the rule:
+//
+//   - IP: an IP address, e.g. "192.0.2.1".
+//   - IPRange: a CIDR, e.g. "192.0.2.0/24". It contains the IPs and
+//     IP ranges inside it.
+//   - Hostname: a hostname, e.g. "www.example.com". It only contains
+//     that hostname.
+//   - DomainName: a domain name, e.g. "example.com". It contains the
+//     domain and all its subdomains.
+//   - DomainPattern: a domain pattern, e.g. "*.example.com". It
+//     contains the hostnames matching the pattern.
+//   - WebAddress: a URL prefix, e.g. "https://example.com/api/". It
+//     contains the web addresses with the same scheme, host and port
+//     under the path.
+//   - AWSAccount: an AWS account ARN or ID, e.g. "123456789012". It
+//     contains the account and the Docker images stored in its ECR
+//     registries.
+//   - DockerImage: a registry prefix, e.g. "ghcr.io/adevinta". It
+//     contains the Docker images under the prefix.
+//
+// The host of web addresses and Git repositories is matched against
+// the IP, IPRange, Hostname, DomainName and DomainPattern rules.
+type ScopeRule struct {
+	// Type is the asset type of the rule.
+	Type AssetType
+	// Value is the value of the rule.
+	Value string
+	// Exclude makes the rule exclude the matching assets from the
+	// scope.
+	Exclude bool
+}
+
+// Scope is a set of in-scope and out-of-scope rules. An asset is in
+// scope if it matches any in-scope rule and it does not match any
+// out-of-scope rule.
+type Scope struct {
+	include []scopeMatcher
+	exclude []scopeMatcher
+}
+
+// NewScope returns a [Scope] built from the provided rules. It
+// returns error if any of the rules is not valid.
+func NewScope(rules []ScopeRule) (*Scope, error) {
+	s := &Scope{}
+	for _, rule := range rules {
+		m, err := newScopeMatcher(rule)
+		if err != nil {
+			return nil, err
+		}
+		if rule.Exclude {
+			s.exclude = append(s.exclude, m)
+		} else {
+			s.include = append(s.include, m)
+		}
+	}
+	return s, nil
+}
+
+// Contains reports whether the asset identified by identifier and
+// type t is in scope.
+func (s *Scope) Contains(identifier string, t AssetType) bool {
+	for _, m := range s.exclude {
+		if m.matches(identifier, t) {
+			return false
+		}
+	}
+	for _, m := range s.include {
+		if m.matches(identifier, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// scopeMatcher is a parsed [ScopeRule].
+type scopeMatcher struct {
+	rule ScopeRule
+
+	ipnet   *net.IPNet
+	host    string
+	pattern DomainWildcard
+	url     *url.URL
+	account string
+	prefix  string
+}
+
+// newScopeMatcher parses and validates a scope rule.
+func newScopeMatcher(rule ScopeRule) (scopeMatcher, error) {
+	m := scopeMatcher{rule: rule}
+
+	var err error
+	switch rule.Type {
+	case IP:
+		value, err := normalizeIP(rule.Value)
+		if err != nil {
+			return m, fmt.Errorf("invalid %v scope rule: %q", rule.Type, rule.Value)
+		}
+		ip := net.ParseIP(value)
+		m.ipnet = &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)}
+	case IPRange:
+		_, m.ipnet, err = net.ParseCIDR(rule.Value)
+	case Hostname, DomainName:
+		if err = ValidateHostname(rule.Value, HostnameOptions{}); err == nil {
+			m.host, err = toASCIIHostname(rule.Value)
+		}
+	case DomainPattern:
+		m.pattern, err = ParseDomainPattern(rule.Value)
+	case WebAddress:
+		if !IsWebAddress(rule.Value) {
+			return m, fmt.Errorf("invalid %v scope rule: %q", rule.Type, rule.Value)
+		}
+		m.url, err = url.Parse(rule.Value)
+	case AWSAccount:
+		m.account = awsAccountID(rule.Value)
+		if m.account == "" {
+			return m, fmt.Errorf("invalid %v scope rule: %q", rule.Type, rule.Value)
+		}
+	case DockerImage:
+		m.prefix = strings.TrimSuffix(rule.Value, "/")
+		if m.prefix == "" || strings.Contains(m.prefix, "://") {
+			return m, fmt.Errorf("invalid %v scope rule: %q", rule.Type, rule.Value)
+		}
+		registry, path, _ := strings.Cut(m.prefix, "/")
+		m.prefix = strings.ToLower(registry)
+		if path != "" {
+			m.prefix += "/" + path
+		}
+	default:
+		return m, fmt.Errorf("unsupported scope rule type: %v", rule.Type)
+	}
+	if err != nil {
+		return m, fmt.Errorf("invalid %v scope rule: %w", rule.Type, err)
+	}
+	return m, nil
+}
+
+// matches reports whether the asset matches the rule.
+func (m scopeMatcher) matches(identifier string, t AssetType) bool {
+	switch t {
+	case IP, IPRange, Hostname, DomainName, DomainPattern:
+		return m.matchesHost(identifier, t)
+	case WebAddress:
+		u, err := url.Parse(identifier)
+		if err != nil || !IsWebAddress(identifier) {
+			return false
+		}
+		if m.rule.Type == WebAddress {
+			return m.matchesURL(u)
+		}
+		return m.matchesHost(u.Hostname(), hostAssetType(u.Hostname()))
+	case ServiceURL:
+		ref, err := ParseServiceURL(identifier)
+		if err != nil {
+			return false
+		}
+		return m.matchesHost(ref.Host, hostAssetType(ref.Host))
+	case NetworkService:
+		addr, err := ParseNetworkService(identifier)
+		if err != nil {
+			return false
+		}
+		return m.matchesHost(addr.Host, hostAssetType(addr.Host))
+	case GitRepository:
+		if !IsGitRepository(identifier) {
+			return false
+		}
+		host := gitRepositoryHost(identifier)
+		return m.matchesHost(host, hostAssetType(host))
+	case AWSAccount:
+		if m.rule.Type != AWSAccount || !IsAWSAccount(identifier) {
+			return false
+		}
+		return awsAccountID(identifier) == m.account
+	case DockerImage:
+		ref, err := ParseImageReference(identifier)
+		if err != nil {
+			return false
+		}
+		switch m.rule.Type {
+		case AWSAccount:
+			return ref.Provider == ECR && ref.Account == m.account
+		case DockerImage:
+			name := strings.ToLower(ref.Registry) + "/" + ref.Repository
+			return name == m.prefix || strings.HasPrefix(name, m.prefix+"/")
+		}
+	}
+	return false
+}
+
+// matchesHost reports whether the IP, IP range, hostname, domain name
+// or domain pattern matches the rule.
+func (m scopeMatcher) matchesHost(identifier string, t AssetType) bool {
+	switch t {
+	case IP:
+		if m.ipnet == nil {
+			return false
+		}
+		// IPs can be written as single address CIDRs, e.g.
+		// 192.0.2.1/32.
+		ip, err := normalizeIP(identifier)
+		if err != nil {
+			return false
+		}
+		return m.ipnet.Contains(net.ParseIP(ip))
+	case IPRange:
+		if m.ipnet == nil {
+			return false
+		}
+		_, n, err := net.ParseCIDR(identifier)
+		if err != nil {
+			return false
+		}
+		ones, _ := n.Mask.Size()
+		rOnes, _ := m.ipnet.Mask.Size()
+		return m.ipnet.Contains(n.IP) && ones >= rOnes
+	case Hostname, DomainName:
+		name, err := toASCIIHostname(identifier)
+		if err != nil {
+			return false
+		}
+		switch m.rule.Type {
+		case Hostname:
+			return name == m.host
+		case DomainName:
+			return isSubdomain(name, m.host)
+		case DomainPattern:
+			return m.pattern.Matches(name)
+		}
+	case DomainPattern:
+		p, err := ParseDomainPattern(identifier)
+		if err != nil {
+			return false
+		}
+		switch m.rule.Type {
+		case DomainName:
+			return isSubdomain(p.Domain, m.host)
+		case DomainPattern:
+			return p == m.pattern
+		}
+	}
+	return false
+}
+
+// matchesURL reports whether the URL is under the URL prefix of the
+// rule.
+func (m scopeMatcher) matchesURL(u *url.URL) bool {
+	if !strings.EqualFold(u.Scheme, m.url.Scheme) || !strings.EqualFold(u.Hostname(), m.url.Hostname()) {
+		return false
+	}
+	if urlPort(u) != urlPort(m.url) {
+		return false
+	}
+
+	// Compare the canonical paths, so dot segments and
+	// percent-encodings cannot be used to escape the prefix.
+	p := canonicalPath(u)
+	prefix := strings.TrimSuffix(canonicalPath(m.url), "/")
+	return prefix == "" || p == prefix || strings.HasPrefix(p, prefix+"/")
+}
+
+// urlPort returns the port of the URL, or the default port of its
+// scheme if it is not specified.
+func urlPort(u *url.URL) string {
+	if p := u.Port(); p != "" {
+		return p
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http":
+		return "80"
+	case "https":
+		return "443"
+	}
+	return ""
+}
+
+// hostAssetType returns the asset type of the host of a URL.
+func hostAssetType(host string) AssetType {
+	if IsIP(host) {
+		return IP
+	}
+	return Hostname
+}
+
+// isSubdomain reports whether name is domain or any of its
+// subdomains.
+func isSubdomain(name, domain string) bool {
+	return name == domain || strings.HasSuffix(name, "."+domain)
+}
+
+// awsAccountID returns the account ID of an AWS account ARN or ID. It
+// returns an empty string if target is not an AWS account.
+func awsAccountID(target string) string {
+	if awsAccountIDRegexp.MatchString(target) {
+		return target
+	}
+	if !IsAWSAccount(target) {
+		return ""
+	}
+	a, _ := arn.Parse(target)
+	return a.AccountID
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"testing"
+)
+
+func TestScope_Contains(t *testing.T) {
+	rules := []ScopeRule{
+		{Type: IPRange, Value: "192.0.2.0/24"},
+		{Type: IP, Value: "192.0.2.10", Exclude: true},
+		{Type: IP, Value: "2001:db8::1"},
+		{Type: DomainName, Value: "example.com"},
+		{Type: Hostname, Value: "internal.example.com", Exclude: true},
+		{Type: DomainPattern, Value: "*.example.org"},
+		{Type: Hostname, Value: "www.example.net"},
+		{Type: WebAddress, Value: "https://api.example.io/v1/"},
+		{Type: WebAddress, Value: "https://api.example.io/v1/admin/", Exclude: true},
+		{Type: AWSAccount, Value: "123456789012"},
+		{Type: DockerImage, Value: "ghcr.io/adevinta"},
+	}
+
+	s, err := NewScope(rules)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		identifier string
+		assetType  AssetType
+		want       bool
+	}{
+		{
+			name:       "IP in range",
+			identifier: "192.0.2.1",
+			assetType:  IP,
+			want:       true,
+		},
+		{
+			name:       "Single IP CIDR in range",
+			identifier: "192.0.2.1/32",
+			assetType:  IP,
+			want:       true,
+		},
+		{
+			name:       "Excluded single IP CIDR",
+			identifier: "192.0.2.10/32",
+			assetType:  IP,
+			want:       false,
+		},
+		{
+			name:       "Excluded IP",
+			identifier: "192.0.2.10",
+			assetType:  IP,
+			want:       false,
+		},
+		{
+			name:       "IP out of range",
+			identifier: "198.51.100.1",
+			assetType:  IP,
+			want:       false,
+		},
+		{
+			name:       "IPv6",
+			identifier: "2001:db8::1",
+			assetType:  IP,
+			want:       true,
+		},
+		{
+			name:       "Subnet",
+			identifier: "192.0.2.128/25",
+			assetType:  IPRange,
+			want:       true,
+		},
+		{
+			name:       "Supernet",
+			identifier: "192.0.0.0/16",
+			assetType:  IPRange,
+			want:       false,
+		},
+		{
+			name:       "Domain",
+			identifier: "example.com",
+			assetType:  DomainName,
+			want:       true,
+		},
+		{
+			name:       "Subdomain",
+			identifier: "www.EXAMPLE.com",
+			assetType:  Hostname,
+			want:       true,
+		},
+		{
+			name:       "Excluded hostname",
+			identifier: "internal.example.com",
+			assetType:  Hostname,
+			want:       false,
+		},
+		{
+			name:       "Similar domain",
+			identifier: "badexample.com",
+			assetType:  Hostname,
+			want:       false,
+		},
+		{
+			name:       "Domain pattern under domain",
+			identifier: "*.dev.example.com",
+			assetType:  DomainPattern,
+			want:       true,
+		},
+		{
+			name:       "Hostname matching pattern",
+			identifier: "www.example.org",
+			assetType:  Hostname,
+			want:       true,
+		},
+		{
+			name:       "Hostname not matching pattern",
+			identifier: "a.www.example.org",
+			assetType:  Hostname,
+			want:       false,
+		},
+		{
+			name:       "Exact hostname",
+			identifier: "www.example.net",
+			assetType:  Hostname,
+			want:       true,
+		},
+		{
+			name:       "Subdomain of exact hostname",
+			identifier: "a.www.example.net",
+			assetType:  Hostname,
+			want:       false,
+		},
+		{
+			name:       "Web address under domain",
+			identifier: "https://www.example.com/login",
+			assetType:  WebAddress,
+			want:       true,
+		},
+		{
+			name:       "Web address on IP in range",
+			identifier: "http://192.0.2.1:8080/",
+			assetType:  WebAddress,
+			want:       true,
+		},
+		{
+			name:       "Web address under URL prefix",
+			identifier: "https://api.example.io:443/v1/users",
+			assetType:  WebAddress,
+			want:       true,
+		},
+		{
+			name:       "Web address URL prefix",
+			identifier: "https://api.example.io/v1",
+			assetType:  WebAddress,
+			want:       true,
+		},
+		{
+			name:       "Web address out of URL prefix",
+			identifier: "https://api.example.io/v10",
+			assetType:  WebAddress,
+			want:       false,
+		},
+		{
+			name:       "Web address with dot segments under URL prefix",
+			identifier: "https://api.example.io/v1/x/../users",
+			assetType:  WebAddress,
+			want:       true,
+		},
+		{
+			name:       "Web address escaping URL prefix with dot segments",
+			identifier: "https://api.example.io/v1/../secret",
+			assetType:  WebAddress,
+			want:       false,
+		},
+		{
+			name:       "Web address escaping URL prefix with encoded dot segments",
+			identifier: "https://api.example.io/v1/%2e%2e/secret",
+			assetType:  WebAddress,
+			want:       false,
+		},
+		{
+			name:       "Excluded web address",
+			identifier: "https://api.example.io/v1/admin/users",
+			assetType:  WebAddress,
+			want:       false,
+		},
+		{
+			name:       "Excluded web address with dot segments",
+			identifier: "https://api.example.io/v1/x/../admin/users",
+			assetType:  WebAddress,
+			want:       false,
+		},
+		{
+			name:       "Excluded web address with encoded characters",
+			identifier: "https://api.example.io/v1/%61dmin/users",
+			assetType:  WebAddress,
+			want:       false,
+		},
+		{
+			name:       "Web address with different scheme",
+			identifier: "http://api.example.io/v1/users",
+			assetType:  WebAddress,
+			want:       false,
+		},
+		{
+			name:       "Git repository under domain",
+			identifier: "git@git.example.com:team/repo.git",
+			assetType:  GitRepository,
+			want:       true,
+		},
+		{
+			name:       "AWS account",
+			identifier: "arn:aws:iam::123456789012:root",
+			assetType:  AWSAccount,
+			want:       true,
+		},
+		{
+			name:       "Other AWS account",
+			identifier: "arn:aws:iam::210987654321:root",
+			assetType:  AWSAccount,
+			want:       false,
+		},
+		{
+			name:       "ECR image of AWS account",
+			identifier: "123456789012.dkr.ecr.eu-west-1.amazonaws.com/app:1.0",
+			assetType:  DockerImage,
+			want:       true,
+		},
+		{
+			name:       "Image under registry prefix",
+			identifier: "ghcr.io/adevinta/vulcan-types:latest",
+			assetType:  DockerImage,
+			want:       true,
+		},
+		{
+			name:       "Image out of registry prefix",
+			identifier: "ghcr.io/adevinta-fork/vulcan-types",
+			assetType:  DockerImage,
+			want:       false,
+		},
+		{
+			name:       "Mismatched type",
+			identifier: "example.com",
+			assetType:  IP,
+			want:       false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.Contains(tt.identifier, tt.assetType); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewScope(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    ScopeRule
+		wantErr bool
+	}{
+		{
+			name: "Valid CIDR",
+			rule: ScopeRule{Type: IPRange, Value: "192.0.2.0/24"},
+		},
+		{
+			name: "AWS account ARN",
+			rule: ScopeRule{Type: AWSAccount, Value: "arn:aws:iam::123456789012:root"},
+		},
+		{
+			name:    "Invalid CIDR",
+			rule:    ScopeRule{Type: IPRange, Value: "192.0.2.0"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid domain",
+			rule:    ScopeRule{Type: DomainName, Value: "foo..bar"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid URL prefix",
+			rule:    ScopeRule{Type: WebAddress, Value: "ftp://example.com"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid AWS account",
+			rule:    ScopeRule{Type: AWSAccount, Value: "1234"},
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported type",
+			rule:    ScopeRule{Type: GitRepository, Value: "git@github.com:adevinta/vulcan-types.git"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewScope([]ScopeRule{tt.rule})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/miekg/dns"
+)
+
+// maxTakeoverCNAMEChain is the maximum length of the CNAME chains
+// followed by [CheckTakeover].
+const maxTakeoverCNAMEChain = 16
+
+//go:embed data/takeover_fingerprints.json
+var embeddedTakeoverFingerprints []byte
+
+var (
+	// takeoverOnce guards the lazy parsing of the embedded takeover
+	// fingerprints.
+	takeoverOnce sync.Once
+
+	// takeoverFingerprints contains the fingerprints used by
+	// [CheckTakeover].
+	takeoverFingerprints atomic.Pointer[[]TakeoverFingerprint]
+)
+
+// TakeoverFingerprint identifies a provider whose resources can be
+// claimed by a third party once deprovisioned, leaving the CNAME
+// records that point to them vulnerable to subdomain takeover.
+type TakeoverFingerprint struct {
+	// Service is the name of the provider service.
+	Service string `json:"service"`
+
+	// CNAME contains the regular expressions matching the CNAME
+	// targets of the service, in A-label form and without the
+	// trailing dot.
+	CNAME []string `json:"cname"`
+
+	// NXDomainOnly reports whether a takeover is only possible when
+	// the CNAME target does not resolve. If false, the target may
+	// resolve even if the resource has been deprovisioned, and the
+	// takeover must be verified with an HTTP request.
+	NXDomainOnly bool `json:"nxdomain_only"`
+
+	// HTTPFingerprint is the text returned by the service for
+	// deprovisioned resources, which can be used to verify the
+	// takeover.
+	HTTPFingerprint string `json:"http_fingerprint,omitempty"`
+
+	cnameRegexps []*regexp.Regexp
+}
+
+// matches reports whether the CNAME target belongs to the service.
+func (fp TakeoverFingerprint) matches(target string) bool {
+	target = strings.ToLower(target)
+	for _, re := range fp.cnameRegexps {
+		if re.MatchString(target) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTakeoverFingerprints parses a JSON array of takeover
+// fingerprints.
+func parseTakeoverFingerprints(data []byte) ([]TakeoverFingerprint, error) {
+	var fps []TakeoverFingerprint
+	if err := json.Unmarshal(data, &fps); err != nil {
+		return nil, fmt.Errorf("invalid takeover fingerprints: %w", err)
+	}
+	for i, fp := range fps {
+		if fp.Service == "" || len(fp.CNAME) == 0 {
+			return nil, fmt.Errorf("invalid takeover fingerprint %v: missing service or CNAME", i)
+		}
+		for _, expr := range fp.CNAME {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid takeover fingerprint %v: %w", fp.Service, err)
+			}
+			fps[i].cnameRegexps = append(fps[i].cnameRegexps, re)
+		}
+	}
+	return fps, nil
+}
+
+// currentTakeoverFingerprints returns the takeover fingerprints used
+// by the package functions, parsing the embedded ones if they have
+// not been replaced.
+func currentTakeoverFingerprints() []TakeoverFingerprint {
+	takeoverOnce.Do(func() {
+		fps, err := parseTakeoverFingerprints(embeddedTakeoverFingerprints)
+		if err != nil {
+			panic(fmt.Sprintf("invalid embedded takeover fingerprints: %v", err))
+		}
+		takeoverFingerprints.CompareAndSwap(nil, &fps)
+	})
+	return *takeoverFingerprints.Load()
+}
+
+// UpdateTakeoverFingerprints replaces the embedded takeover
+// fingerprints with the ones stored in the provided file. The file
+// must contain a JSON array of [TakeoverFingerprint].
+func UpdateTakeoverFingerprints(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read takeover fingerprints: %w", err)
+	}
+
+	fps, err := parseTakeoverFingerprints(data)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the embedded fingerprints do not overwrite the new
+	// ones.
+	takeoverOnce.Do(func() {})
+	takeoverFingerprints.Store(&fps)
+	return nil
+}
+
+// TakeoverStatus is the result of a subdomain takeover check.
+type TakeoverStatus string
+
+// Takeover statuses.
+const (
+	// TakeoverNotVulnerable means that the hostname is not an alias
+	// or its CNAME chain does not reveal any issue.
+	TakeoverNotVulnerable TakeoverStatus = "not-vulnerable"
+	// TakeoverVulnerable means that the CNAME target does not resolve
+	// and belongs to a takeover-prone service.
+	TakeoverVulnerable TakeoverStatus = "vulnerable"
+	// TakeoverDangling means that the CNAME target does not resolve
+	// but it does not match any known service.
+	TakeoverDangling TakeoverStatus = "dangling"
+	// TakeoverNeedsVerification means that the CNAME target belongs
+	// to a takeover-prone service whose deprovisioned resources still
+	// resolve, so the takeover must be verified with an HTTP request.
+	TakeoverNeedsVerification TakeoverStatus = "needs-verification"
+)
+
+// TakeoverCheck is the result of [CheckTakeover].
+type TakeoverCheck struct {
+	// Hostname is the checked hostname, in A-label form.
+	Hostname string
+	// CNAMEChain contains the CNAME records followed from the
+	// hostname.
+	CNAMEChain []CNAMERecord
+	// Dangling reports whether the last CNAME target does not
+	// resolve (NXDOMAIN).
+	Dangling bool
+	// Fingerprint is the fingerprint matching a target of the CNAME
+	// chain, if any.
+	Fingerprint *TakeoverFingerprint
+	// Status is the result of the check.
+	Status TakeoverStatus
+}
+
+// CheckTakeover follows the CNAME chain of a hostname using the DNS
+// servers in /etc/resolv.conf and reports whether it is vulnerable to
+// subdomain takeover, that is, whether it points to a deprovisioned
+// resource of a takeover-prone service, like an Azure App Service or
+// a Heroku app. The targets of the chain are matched against an
+// embedded list of fingerprints, which can be replaced with
+// [UpdateTakeoverFingerprints].
+//
+// It returns an error wrapping [ErrDNSQuery] if the DNS servers cannot
+// be queried.
+func CheckTakeover(ctx context.Context, hostname string) (TakeoverCheck, error) {
+	return CheckTakeoverWithOptions(ctx, hostname, TakeoverOptions{})
+}
+
+// TakeoverOptions configures [CheckTakeoverWithOptions].
+type TakeoverOptions struct {
+	// Resolver is the address of the DNS server used to follow the
+	// CNAME chain, e.g. "192.0.2.53:53". The port defaults to 53. If
+	// empty, the servers in /etc/resolv.conf are used.
+	Resolver string
+}
+
+// CheckTakeoverWithOptions is like [CheckTakeover] but allows to
+// configure the DNS server to query.
+func CheckTakeoverWithOptions(ctx context.Context, hostname string, opts TakeoverOptions) (TakeoverCheck, error) {
+	name, err := toASCIILookupName(hostname)
+	if err != nil {
+		return TakeoverCheck{}, fmt.Errorf("invalid hostname: %w", err)
+	}
+
+	r, _, err := dnsQuery(ctx, dns.Fqdn(name), dns.TypeA, opts.Resolver)
+	if err != nil {
+		return TakeoverCheck{}, fmt.Errorf("%w: %w", ErrDNSQuery, err)
+	}
+
+	check := TakeoverCheck{Hostname: name, Status: TakeoverNotVulnerable}
+	check.CNAMEChain, _ = parseAddressAnswer(r, dns.Fqdn(name))
+	if len(check.CNAMEChain) == 0 {
+		return check, nil
+	}
+
+	// The response code of the query does not necessarily refer to
+	// the last CNAME target, e.g. when the server does not follow
+	// targets outside of its zone. So, the last target is queried
+	// until the end of the chain is reached.
+	seen := map[string]bool{name: true}
+	for len(check.CNAMEChain) < maxTakeoverCNAMEChain {
+		target := check.CNAMEChain[len(check.CNAMEChain)-1].Target
+		if seen[strings.ToLower(target)] {
+			break
+		}
+		seen[strings.ToLower(target)] = true
+
+		r, _, err := dnsQuery(ctx, dns.Fqdn(target), dns.TypeA, opts.Resolver)
+		if err != nil {
+			return TakeoverCheck{}, fmt.Errorf("%w: %w", ErrDNSQuery, err)
+		}
+		chain, _ := parseAddressAnswer(r, dns.Fqdn(target))
+		if len(chain) == 0 {
+			check.Dangling = r.Rcode == dns.RcodeNameError
+			break
+		}
+		check.CNAMEChain = append(check.CNAMEChain, chain...)
+	}
+
+	fps := currentTakeoverFingerprints()
+	for _, c := range check.CNAMEChain {
+		for i := range fps {
+			if fps[i].matches(c.Target) {
+				fp := fps[i]
+				check.Fingerprint = &fp
+				break
+			}
+		}
+		if check.Fingerprint != nil {
+			break
+		}
+	}
+
+	switch {
+	case check.Dangling && check.Fingerprint != nil:
+		check.Status = TakeoverVulnerable
+	case check.Dangling:
+		check.Status = TakeoverDangling
+	case check.Fingerprint != nil && !check.Fingerprint.NXDomainOnly:
+		check.Status = TakeoverNeedsVerification
+	}
+	return check, nil
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestCheckTakeover(t *testing.T) {
+	startTestDNSServer(t, testZone{
+		"app.example.test. 300 IN CNAME gone-app.azurewebsites.net.",
+		"blog.example.test. 300 IN CNAME adevinta.github.io.",
+		"adevinta.github.io. 300 IN A 185.199.108.153",
+		"old.example.test. 300 IN CNAME old.unknown-provider.test.",
+		"www.example.test. 300 IN A 192.0.2.1",
+		"cdn.example.test. 300 IN CNAME edge.example.test.",
+		"edge.example.test. 300 IN A 192.0.2.2",
+	})
+
+	tests := []struct {
+		name        string
+		hostname    string
+		want        TakeoverCheck
+		wantService string
+	}{
+		{
+			name:     "dangling CNAME to takeover-prone service",
+			hostname: "app.example.test",
+			want: TakeoverCheck{
+				Hostname:   "app.example.test",
+				CNAMEChain: []CNAMERecord{{Name: "app.example.test", Target: "gone-app.azurewebsites.net", TTL: 5 * time.Minute}},
+				Dangling:   true,
+				Status:     TakeoverVulnerable,
+			},
+			wantService: "Azure App Service",
+		},
+		{
+			name:     "resolvable CNAME to service requiring verification",
+			hostname: "blog.example.test",
+			want: TakeoverCheck{
+				Hostname:   "blog.example.test",
+				CNAMEChain: []CNAMERecord{{Name: "blog.example.test", Target: "adevinta.github.io", TTL: 5 * time.Minute}},
+				Status:     TakeoverNeedsVerification,
+			},
+			wantService: "GitHub Pages",
+		},
+		{
+			name:     "dangling CNAME to unknown service",
+			hostname: "old.example.test",
+			want: TakeoverCheck{
+				Hostname:   "old.example.test",
+				CNAMEChain: []CNAMERecord{{Name: "old.example.test", Target: "old.unknown-provider.test", TTL: 5 * time.Minute}},
+				Dangling:   true,
+				Status:     TakeoverDangling,
+			},
+		},
+		{
+			name:     "resolvable CNAME",
+			hostname: "cdn.example.test",
+			want: TakeoverCheck{
+				Hostname:   "cdn.example.test",
+				CNAMEChain: []CNAMERecord{{Name: "cdn.example.test", Target: "edge.example.test", TTL: 5 * time.Minute}},
+				Status:     TakeoverNotVulnerable,
+			},
+		},
+		{
+			name:     "not an alias",
+			hostname: "www.example.test",
+			want:     TakeoverCheck{Hostname: "www.example.test", Status: TakeoverNotVulnerable},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckTakeover(context.Background(), tt.hostname)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var service string
+			if got.Fingerprint != nil {
+				service = got.Fingerprint.Service
+			}
+			if service != tt.wantService {
+				t.Errorf("got service %q, want %q", service, tt.wantService)
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreFields(TakeoverCheck{}, "Fingerprint")); diff != "" {
+				t.Errorf("check mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestCheckTakeover_nonRecursive(t *testing.T) {
+	// The server does not follow CNAME records, so the response to
+	// the hostname query is NOERROR even if the target does not
+	// exist.
+	resolver := startTestDNSHandler(t, zoneHandler(t, testZone{
+		"app.example.test. 300 IN CNAME gone-app.azurewebsites.net.",
+		"api.example.test. 300 IN CNAME api.internal.example.test.",
+		"api.internal.example.test. 300 IN CNAME gone-api.azurewebsites.net.",
+		"cdn.example.test. 300 IN CNAME edge.example.test.",
+		"edge.example.test. 300 IN A 192.0.2.2",
+		"loop.example.test. 300 IN CNAME loop.example.test.",
+	}, false))
+
+	tests := []struct {
+		name     string
+		hostname string
+		want     TakeoverCheck
+	}{
+		{
+			name:     "dangling CNAME",
+			hostname: "app.example.test",
+			want: TakeoverCheck{
+				Hostname:   "app.example.test",
+				CNAMEChain: []CNAMERecord{{Name: "app.example.test", Target: "gone-app.azurewebsites.net", TTL: 5 * time.Minute}},
+				Dangling:   true,
+				Status:     TakeoverVulnerable,
+			},
+		},
+		{
+			name:     "dangling CNAME chain",
+			hostname: "api.example.test",
+			want: TakeoverCheck{
+				Hostname: "api.example.test",
+				CNAMEChain: []CNAMERecord{
+					{Name: "api.example.test", Target: "api.internal.example.test", TTL: 5 * time.Minute},
+					{Name: "api.internal.example.test", Target: "gone-api.azurewebsites.net", TTL: 5 * time.Minute},
+				},
+				Dangling: true,
+				Status:   TakeoverVulnerable,
+			},
+		},
+		{
+			name:     "resolvable CNAME",
+			hostname: "cdn.example.test",
+			want: TakeoverCheck{
+				Hostname:   "cdn.example.test",
+				CNAMEChain: []CNAMERecord{{Name: "cdn.example.test", Target: "edge.example.test", TTL: 5 * time.Minute}},
+				Status:     TakeoverNotVulnerable,
+			},
+		},
+		{
+			name:     "CNAME loop",
+			hostname: "loop.example.test",
+			want: TakeoverCheck{
+				Hostname:   "loop.example.test",
+				CNAMEChain: []CNAMERecord{{Name: "loop.example.test", Target: "loop.example.test", TTL: 5 * time.Minute}},
+				Status:     TakeoverNotVulnerable,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckTakeoverWithOptions(context.Background(), tt.hostname, TakeoverOptions{Resolver: resolver})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreFields(TakeoverCheck{}, "Fingerprint")); diff != "" {
+				t.Errorf("check mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateTakeoverFingerprints(t *testing.T) {
+	prev := currentTakeoverFingerprints()
+	t.Cleanup(func() { takeoverFingerprints.Store(&prev) })
+
+	startTestDNSServer(t, testZone{
+		"shop.example.test. 300 IN CNAME shop.example-saas.test.",
+	})
+
+	path := filepath.Join(t.TempDir(), "fingerprints.json")
+	data := `[{"service": "Example SaaS", "cname": ["\\.example-saas\\.test$"], "nxdomain_only": true}]`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write fingerprints: %v", err)
+	}
+	if err := UpdateTakeoverFingerprints(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := CheckTakeover(context.Background(), "shop.example.test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Status != TakeoverVulnerable || got.Fingerprint == nil || got.Fingerprint.Service != "Example SaaS" {
+		t.Errorf("unexpected check: %+v", got)
+	}
+
+	invalid := filepath.Join(t.TempDir(), "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`[{"service": "Broken", "cname": ["("]}]`), 0o644); err != nil {
+		t.Fatalf("write fingerprints: %v", err)
+	}
+	if err := UpdateTakeoverFingerprints(invalid); err == nil {
+		t.Error("expected error for invalid fingerprints")
+	}
+}
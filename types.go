@@ -6,12 +6,16 @@ package types
 
 import (
 	"context"
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"net"
+	"net/netip"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/distribution/reference"
@@ -23,9 +27,16 @@ const (
 )
 
 var (
+	// dnsConfMu guards dnsConf.
+	dnsConfMu sync.Mutex
+
 	dnsConf *dns.ClientConfig
 )
 
+// ErrDNSQuery is returned when a check cannot be completed because a
+// DNS query failed.
+var ErrDNSQuery = errors.New("DNS query failed")
+
 // IsIP returns true if the target is an IP address.
 func IsIP(target string) bool {
 	return net.ParseIP(target) != nil
@@ -62,12 +73,43 @@ func IsGitRepository(target string) bool {
 //
 //   - It has a non-empty scheme (http or https)
 //   - It has a non-empty hostname
+//   - If present, the port is in the range 1-65535
+//
+// The host can be a hostname, an IPv4 address or a bracketed IPv6
+// address, optionally with a zone, e.g. "https://[fe80::1%25eth0]:8443/".
 func IsWebAddress(target string) bool {
 	u, err := url.ParseRequestURI(target)
 	if err != nil {
 		return false
 	}
-	return u.IsAbs() && (u.Scheme == "https" || u.Scheme == "http") && u.Hostname() != ""
+	if !u.IsAbs() || (u.Scheme != "https" && u.Scheme != "http") || u.Hostname() == "" {
+		return false
+	}
+	if port := u.Port(); port != "" {
+		n, err := strconv.Atoi(port)
+		if err != nil || n < 1 || n > 65535 {
+			return false
+		}
+	}
+	if strings.HasPrefix(u.Host, "[") {
+		_, ok := urlHostIP(u)
+		return ok
+	}
+	return true
+}
+
+// urlHostIP returns the IP address of a URL whose host is an IP
+// literal, without the zone, if any. IPv6 addresses must be enclosed
+// in brackets and IPv4 addresses must not.
+func urlHostIP(u *url.URL) (string, bool) {
+	addr, err := netip.ParseAddr(u.Hostname())
+	if err != nil {
+		return "", false
+	}
+	if strings.HasPrefix(u.Host, "[") != addr.Is6() {
+		return "", false
+	}
+	return addr.WithZone("").String(), true
 }
 
 // IsAWSARN returns true if the target is an AWS ARN.
@@ -137,18 +179,57 @@ func IsDockerImage(target string) bool {
 
 // IsDomainName returns true if a query to a domain server returns a SOA record for the
 // target.
+//
+// Internationalized domain names are converted to their A-label form before
+// querying the domain server.
 func IsDomainName(target string) (bool, error) {
-	return hasSOARecord(target)
+	return isDomainName(target, "")
 }
 
-func hasSOARecord(target string) (bool, error) {
-	var err error
+// isDomainName is like [IsDomainName] but queries the provided DNS
+// server. If resolver is empty, the servers in /etc/resolv.conf are
+// queried.
+func isDomainName(target, resolver string) (bool, error) {
+	name, err := toASCIILookupName(target)
+	if err != nil {
+		return false, nil
+	}
+	return hasSOARecord(name, resolver)
+}
+
+// dnsServers returns the addresses of the DNS servers to query. If
+// resolver is empty, the servers in /etc/resolv.conf are returned.
+func dnsServers(resolver string) ([]string, error) {
+	if resolver != "" {
+		if _, _, err := net.SplitHostPort(resolver); err != nil {
+			resolver = net.JoinHostPort(resolver, "53")
+		}
+		return []string{resolver}, nil
+	}
+
+	dnsConfMu.Lock()
+	defer dnsConfMu.Unlock()
+
 	// Read the local dns server config only the first time.
 	if dnsConf == nil {
-		dnsConf, err = dns.ClientConfigFromFile(dnsConfFilePath)
+		conf, err := dns.ClientConfigFromFile(dnsConfFilePath)
 		if err != nil {
-			return false, err
+			return nil, err
 		}
+		dnsConf = conf
+	}
+
+	var addrs []string
+	for _, srv := range dnsConf.Servers {
+		addrs = append(addrs, net.JoinHostPort(srv, dnsConf.Port))
+	}
+	return addrs, nil
+}
+
+func hasSOARecord(target, resolver string) (bool, error) {
+	servers, err := dnsServers(resolver)
+	if err != nil {
+		return false, err
 	}
 
 	target = target + "."
@@ -159,9 +240,7 @@ func hasSOARecord(target string) (bool, error) {
 	c := dns.Client{}
 	var r *dns.Msg
 	// Try to get an answer using local configured dns servers.
-	for _, srv := range dnsConf.Servers {
-		address := fmt.Sprintf("%s:%s", srv, dnsConf.Port)
-
+	for _, address := range servers {
 		r, _, err = c.Exchange(m, address)
 		if err != nil {
 			return false, err
@@ -199,14 +278,27 @@ func soaHeaderForName(r *dns.Msg, name string) bool {
 }
 
 // IsHostname returns true if the target is not an IP but can be resolved to an IP.
+//
+// Internationalized hostnames are converted to their A-label form before being
+// resolved.
 func IsHostname(target string) bool {
+	return isHostname(target, "")
+}
+
+// isHostname is like [IsHostname] but queries the provided DNS server.
+// If resolver is empty, the servers in /etc/resolv.conf are queried.
+func isHostname(target, resolver string) bool {
 	// If the target is an IP can not be a hostname.
 	if IsIP(target) {
 		return false
 	}
 
-	resolv := &net.Resolver{PreferGo: true}
-	r, err := resolv.LookupHost(context.Background(), target)
+	name, err := toASCIILookupName(target)
+	if err != nil {
+		return false
+	}
+
+	r, err := newResolver(resolver).LookupHost(context.Background(), name)
 	if err != nil {
 		return false
 	}
@@ -214,15 +306,46 @@ func IsHostname(target string) bool {
 	return len(r) > 0
 }
 
-// IsHostnameNoDNSResolution returns true if the target is not an IP.
+// newResolver returns a [net.Resolver] that queries the provided DNS
+// server. If resolver is empty, the servers in /etc/resolv.conf are
+// queried.
+func newResolver(resolver string) *net.Resolver {
+	if resolver == "" {
+		return &net.Resolver{PreferGo: true}
+	}
+
+	servers, _ := dnsServers(resolver)
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, servers[0])
+		},
+	}
+}
+
+// IsHostnameNoDNSResolution returns true if the target is not an IP and it is a
+// syntactically valid hostname according to [ValidateHostname].
+//
+// Internationalized hostnames are converted to their A-label form before being
+// checked.
 func IsHostnameNoDNSResolution(target string) bool {
 	// If the target is an IP can not be a hostname.
 	if IsIP(target) {
 		return false
 	}
 
+	if err := ValidateHostname(target, HostnameOptions{}); err != nil {
+		return false
+	}
+
+	name, err := toASCIIHostname(target)
+	if err != nil {
+		return false
+	}
+
 	// We don't want to onboard TLDs to vulcan
-	if !strings.Contains(target, ".") {
+	if !strings.Contains(name, ".") {
 		return false
 	}
 
@@ -255,14 +378,17 @@ type AssetType string
 
 // Asset types for vulcan assets.
 const (
-	AWSAccount    AssetType = "AWSAccount"
-	DockerImage   AssetType = "DockerImage"
-	GitRepository AssetType = "GitRepository"
-	IP            AssetType = "IP"
-	IPRange       AssetType = "IPRange"
-	DomainName    AssetType = "DomainName"
-	Hostname      AssetType = "Hostname"
-	WebAddress    AssetType = "WebAddress"
+	AWSAccount     AssetType = "AWSAccount"
+	DockerImage    AssetType = "DockerImage"
+	GitRepository  AssetType = "GitRepository"
+	IP             AssetType = "IP"
+	IPRange        AssetType = "IPRange"
+	DomainName     AssetType = "DomainName"
+	Hostname       AssetType = "Hostname"
+	WebAddress     AssetType = "WebAddress"
+	DomainPattern  AssetType = "DomainPattern"
+	ServiceURL     AssetType = "ServiceURL"
+	NetworkService AssetType = "NetworkService"
 )
 
 // String returns the string representation of the [AssetType].
@@ -272,37 +398,134 @@ func (t AssetType) String() string {
 
 // Parse parses a string representing an asset type into an [AssetType].
 // It returns error if the provided string does not match any known asset
-// type.
+// type, either built-in or registered with [Register].
 func Parse(assetType string) (t AssetType, err error) {
-	switch AssetType(assetType) {
-	case AWSAccount:
-		t = AWSAccount
-	case DockerImage:
-		t = DockerImage
-	case GitRepository:
-		t = GitRepository
-	case IP:
-		t = IP
-	case IPRange:
-		t = IPRange
-	case Hostname:
-		t = Hostname
-	case DomainName:
-		t = DomainName
-	case WebAddress:
-		t = WebAddress
-	default:
-		err = fmt.Errorf("unknown type: %v", assetType)
+	spec, ok := lookupAssetType(AssetType(assetType))
+	if !ok {
+		return "", fmt.Errorf("unknown type: %v", assetType)
 	}
-	return t, err
+	return spec.Name, nil
 }
 
 // DetectAssetTypes detects the asset types from an identifier.
+//
+// Custom asset types registered with [Register] are tried before or after the
+// built-in ones depending on their priority. See [AssetTypeSpec.Priority].
 func DetectAssetTypes(identifier string) ([]AssetType, error) {
+	return DetectAssetTypesWithOptions(identifier, DetectOptions{})
+}
+
+// DetectOptions configures the detection and validation of asset types.
+type DetectOptions struct {
+	// Offline disables network access. Hostnames are checked with
+	// [IsHostnameNoDNSResolution] and only registrable domains, like
+	// "example.com" or "example.co.uk", are considered domain names.
+	Offline bool
+
+	// Resolver is the address of the DNS server used to resolve
+	// hostnames and domain names, e.g. "192.0.2.53:53". The port
+	// defaults to 53. If empty, the servers in /etc/resolv.conf are
+	// used.
+	Resolver string
+
+	// AcceptUnresolvedWebAddresses makes syntactically valid web
+	// addresses be detected as WebAddress even if their hostnames
+	// cannot be resolved, like internal URLs that only resolve inside
+	// a VPN. In that case, Hostname is not reported. Use
+	// [WebAddressHostStatus] to know whether the hostname resolves.
+	AcceptUnresolvedWebAddresses bool
+
+	// OnHostnameResolved, if not nil, is called with the resolution
+	// details of every hostname resolved during the detection, so
+	// callers can create the related IP assets without resolving the
+	// hostname again. See [ResolveHostname]. Names that are only
+	// defined in /etc/hosts are still detected as hostnames, but the
+	// function is not called for them.
+	OnHostnameResolved func(HostnameResolution)
+
+	// OnWildcardHostname, if not nil, is called with every hostname
+	// detected during the detection that only resolves because of a
+	// wildcard record in its parent zone. See [IsWildcardHostname].
+	// Such hostnames are still reported as Hostname, so callers can
+	// decide whether to onboard them.
+	OnWildcardHostname func(hostname string)
+}
+
+// isHostname reports whether target is a hostname according to the
+// options.
+func (opts DetectOptions) isHostname(target string) bool {
+	if opts.Offline {
+		return IsHostnameNoDNSResolution(target)
+	}
+
+	ok := false
+	if opts.OnHostnameResolved != nil {
+		res, err := resolveHostname(context.Background(), target, opts.Resolver)
+		if err == nil {
+			opts.OnHostnameResolved(res)
+			ok = true
+		}
+	}
+	if !ok {
+		ok = isHostname(target, opts.Resolver)
+	}
+
+	if ok && opts.OnWildcardHostname != nil {
+		// Errors are ignored, so the hostname is not marked if the
+		// wildcard detection fails.
+		if wildcard, err := isWildcardHostname(context.Background(), target, opts.Resolver); err == nil && wildcard {
+			opts.OnWildcardHostname(target)
+		}
+	}
+	return ok
+}
+
+// isDomainName reports whether target is a domain name according to
+// the options.
+func (opts DetectOptions) isDomainName(target string) (bool, error) {
+	if opts.Offline {
+		name, err := toASCIIHostname(target)
+		if err != nil {
+			return false, nil
+		}
+		domain, err := RegistrableDomain(name)
+		return err == nil && domain == name, nil
+	}
+	return isDomainName(target, opts.Resolver)
+}
+
+// DetectAssetTypesWithOptions is like [DetectAssetTypes] but allows to
+// configure the detection. See [DetectOptions].
+func DetectAssetTypesWithOptions(identifier string, opts DetectOptions) ([]AssetType, error) {
+	if t, ok := detectCustomAssetType(identifier, func(p int) bool { return p > 0 }); ok {
+		return []AssetType{t}, nil
+	}
+
+	assetTypes, err := detectBuiltinAssetTypes(identifier, opts)
+	if err != nil || len(assetTypes) > 0 {
+		return assetTypes, err
+	}
+
+	if t, ok := detectCustomAssetType(identifier, func(p int) bool { return p <= 0 }); ok {
+		return []AssetType{t}, nil
+	}
+	return nil, nil
+}
+
+// detectBuiltinAssetTypes detects the built-in asset types from an
+// identifier.
+func detectBuiltinAssetTypes(identifier string, opts DetectOptions) ([]AssetType, error) {
 	if IsAWSAccount(identifier) {
 		return []AssetType{AWSAccount}, nil
 	}
 
+	// Network services are checked before Docker images because
+	// addresses like "192.0.2.1:22/tcp" are also valid Docker image
+	// references, although improbable ones.
+	if IsNetworkService(identifier) {
+		return []AssetType{NetworkService}, nil
+	}
+
 	if IsDockerImage(identifier) {
 		return []AssetType{DockerImage}, nil
 	}
@@ -327,6 +550,20 @@ func DetectAssetTypes(identifier string) ([]AssetType, error) {
 		return []AssetType{assetType}, nil
 	}
 
+	if IsServiceURL(identifier) {
+		return []AssetType{ServiceURL}, nil
+	}
+
+	if IsDomainPattern(identifier) {
+		return []AssetType{DomainPattern}, nil
+	}
+
+	// Public suffixes, like "com" or "co.uk", are shared by many
+	// owners and must not be onboarded.
+	if IsPublicSuffix(identifier) {
+		return nil, fmt.Errorf("%w: %v", ErrPublicSuffix, identifier)
+	}
+
 	var assetTypes []AssetType
 
 	isWeb := false
@@ -340,22 +577,32 @@ func DetectAssetTypes(identifier string) ([]AssetType, error) {
 		if err != nil {
 			return nil, err
 		}
+
+		// URLs with an IP literal host, like https://192.0.2.1:8443/,
+		// are also IP assets. There is no need to check the DNS.
+		if _, ok := urlHostIP(u); ok {
+			return []AssetType{IP, WebAddress}, nil
+		}
+
 		// Overwrite identifier to check for hostname and domain.
 		identifier = u.Hostname()
 	}
 
-	if IsHostname(identifier) {
+	if opts.isHostname(identifier) {
 		assetTypes = append(assetTypes, Hostname)
 
-		// Add WebAddress type only for URLs with valid hostnames.
+		// Add WebAddress type only for URLs with valid hostnames,
+		// unless unresolved web addresses are accepted.
 		if isWeb {
 			assetTypes = append(assetTypes, WebAddress)
 		}
+	} else if isWeb && opts.AcceptUnresolvedWebAddresses {
+		assetTypes = append(assetTypes, WebAddress)
 	}
 
-	ok, err := IsDomainName(identifier)
+	ok, err := opts.isDomainName(identifier)
 	if err != nil {
-		return nil, fmt.Errorf("cannot guess if the asset is a domain: %w", err)
+		return nil, fmt.Errorf("%w: cannot guess if the asset is a domain: %w", ErrDNSQuery, err)
 	}
 	if ok {
 		assetTypes = append(assetTypes, DomainName)
@@ -373,3 +620,54 @@ func (t AssetType) IsValid() bool {
 	_, err := Parse(string(t))
 	return err == nil
 }
+
+// MarshalText implements the [encoding.TextMarshaler] interface. It
+// returns error if the [AssetType] is not valid.
+func (t AssetType) MarshalText() ([]byte, error) {
+	if !t.IsValid() {
+		return nil, fmt.Errorf("unknown type: %v", string(t))
+	}
+	return []byte(t), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface,
+// which is also used by the JSON and YAML decoders. It returns error
+// if the text does not match any known asset type. An empty text is
+// decoded as the zero value.
+func (t *AssetType) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*t = ""
+		return nil
+	}
+	at, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*t = at
+	return nil
+}
+
+// Scan implements the [database/sql.Scanner] interface. A NULL value is
+// decoded as the zero value.
+func (t *AssetType) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*t = ""
+		return nil
+	case string:
+		return t.UnmarshalText([]byte(v))
+	case []byte:
+		return t.UnmarshalText(v)
+	default:
+		return fmt.Errorf("cannot scan %T into AssetType", src)
+	}
+}
+
+// Value implements the [driver.Valuer] interface. It returns error if
+// the [AssetType] is not valid.
+func (t AssetType) Value() (driver.Value, error) {
+	if !t.IsValid() {
+		return nil, fmt.Errorf("unknown type: %v", string(t))
+	}
+	return string(t), nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"fmt"
+)
+
+// ValidationError is returned by [Validate] when an identifier is not
+// valid for the declared asset type.
+type ValidationError struct {
+	// Identifier is the validated identifier.
+	Identifier string
+	// Type is the declared asset type.
+	Type AssetType
+	// Err is the error returned by the validation function of the
+	// asset type.
+	Err error
+}
+
+// Error returns the error message.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %v %q: %v", e.Type, e.Identifier, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Validate checks that the identifier is a valid asset of the
+// provided type. Unlike [DetectAssetTypes], it only runs the checker
+// of the declared type, e.g. [IsDockerImage] for DockerImage. Note
+// that a CIDR with a /32 mask is considered an IP and not an IPRange,
+// consistently with [DetectAssetTypes].
+//
+// It returns a [*ValidationError] if the identifier is not valid for
+// the asset type, and a different error if the asset type is not
+// known.
+func Validate(identifier string, t AssetType) error {
+	return ValidateWithOptions(identifier, t, DetectOptions{})
+}
+
+// ValidateWithOptions is like [Validate] but allows to configure how
+// hostnames, domain names and web addresses are checked. See
+// [DetectOptions].
+func ValidateWithOptions(identifier string, t AssetType, opts DetectOptions) error {
+	spec, ok := lookupAssetType(t)
+	if !ok {
+		return fmt.Errorf("unknown type: %v", t)
+	}
+
+	if err := specValidator(spec, opts)(identifier); err != nil {
+		return &ValidationError{Identifier: identifier, Type: t, Err: err}
+	}
+	return nil
+}
+
+// specValidator returns the validation function of the asset type
+// for the provided options. Only the built-in types that require
+// network access depend on the options.
+func specValidator(spec AssetTypeSpec, opts DetectOptions) func(string) error {
+	if !spec.builtin {
+		return spec.Validate
+	}
+	switch spec.Name {
+	case Hostname:
+		return hostnameValidator(opts)
+	case DomainName:
+		return domainNameValidator(opts)
+	case WebAddress:
+		return webAddressValidator(opts)
+	}
+	return spec.Validate
+}
+
+// Normalize returns the canonical form of an identifier of the
+// provided type using its [AssetTypeSpec.Normalize] function. For
+// instance, hostnames are lowercased and converted to A-label form.
+// The identifier is returned unchanged if the asset type has no
+// normalization function. It returns error if the asset type is not
+// known.
+func Normalize(identifier string, t AssetType) (string, error) {
+	spec, ok := lookupAssetType(t)
+	if !ok {
+		return "", fmt.Errorf("unknown type: %v", t)
+	}
+	if spec.Normalize == nil {
+		return identifier, nil
+	}
+	return spec.Normalize(identifier)
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		identifier     string
+		assetType      AssetType
+		wantErr        bool
+		wantValidation bool
+	}{
+		{
+			name:       "AWS account",
+			identifier: "arn:aws:iam::123456789012:root",
+			assetType:  AWSAccount,
+		},
+		{
+			name:           "AWS ARN as AWS account",
+			identifier:     "arn:aws:s3:::bucket_name/key_name",
+			assetType:      AWSAccount,
+			wantErr:        true,
+			wantValidation: true,
+		},
+		{
+			name:       "Docker image",
+			identifier: "ghcr.io/puppeteer/puppeteer",
+			assetType:  DockerImage,
+		},
+		{
+			name:           "Docker image as Git repository",
+			identifier:     "ghcr.io/puppeteer/puppeteer",
+			assetType:      GitRepository,
+			wantErr:        true,
+			wantValidation: true,
+		},
+		{
+			name:       "IP",
+			identifier: "192.0.2.1",
+			assetType:  IP,
+		},
+		{
+			name:       "Single IP CIDR as IP",
+			identifier: "192.0.2.1/32",
+			assetType:  IP,
+		},
+		{
+			name:           "Single IP CIDR as IP range",
+			identifier:     "192.0.2.1/32",
+			assetType:      IPRange,
+			wantErr:        true,
+			wantValidation: true,
+		},
+		{
+			name:       "IP range",
+			identifier: "192.0.2.0/24",
+			assetType:  IPRange,
+		},
+		{
+			name:       "Web address on IP",
+			identifier: "https://192.0.2.1:8443/",
+			assetType:  WebAddress,
+		},
+		{
+			name:           "Hostname as web address",
+			identifier:     "www.example.com",
+			assetType:      WebAddress,
+			wantErr:        true,
+			wantValidation: true,
+		},
+		{
+			name:       "Domain pattern",
+			identifier: "*.example.com",
+			assetType:  DomainPattern,
+		},
+		{
+			name:           "Hostname as domain pattern",
+			identifier:     "www.example.com",
+			assetType:      DomainPattern,
+			wantErr:        true,
+			wantValidation: true,
+		},
+		{
+			name:       "Unknown type",
+			identifier: "192.0.2.1",
+			assetType:  AssetType("Hostnme"),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.identifier, tt.assetType)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+
+			var verr *ValidationError
+			if errors.As(err, &verr) != tt.wantValidation {
+				t.Errorf("got error %#v, want validation error %v", err, tt.wantValidation)
+			}
+		})
+	}
+}
+
+func TestValidateWithOptions(t *testing.T) {
+	resolver := startTestDNSServer(t, testZone{
+		"www.example.test. 3600 IN A 192.0.2.2",
+		"example.test. 3600 IN SOA ns.example.test. admin.example.test. 1 3600 600 86400 60",
+		"co.uk. 3600 IN SOA ns.co.uk. admin.co.uk. 1 3600 600 86400 60",
+		"co.uk. 3600 IN A 192.0.2.3",
+	})
+
+	tests := []struct {
+		name       string
+		identifier string
+		assetType  AssetType
+		opts       DetectOptions
+		wantErr    bool
+		wantErrIs  error
+	}{
+		{
+			name:       "Offline hostname",
+			identifier: "www.adevinta.com",
+			assetType:  Hostname,
+			opts:       DetectOptions{Offline: true},
+		},
+		{
+			name:       "Offline invalid hostname",
+			identifier: "foo..bar",
+			assetType:  Hostname,
+			opts:       DetectOptions{Offline: true},
+			wantErr:    true,
+		},
+		{
+			name:       "Offline domain name",
+			identifier: "adevinta.com",
+			assetType:  DomainName,
+			opts:       DetectOptions{Offline: true},
+		},
+		{
+			name:       "Offline subdomain as domain name",
+			identifier: "www.adevinta.com",
+			assetType:  DomainName,
+			opts:       DetectOptions{Offline: true},
+			wantErr:    true,
+		},
+		{
+			name:       "Domain name",
+			identifier: "example.test",
+			assetType:  DomainName,
+			opts:       DetectOptions{Resolver: resolver},
+		},
+		{
+			name:       "Public suffix as domain name",
+			identifier: "co.uk",
+			assetType:  DomainName,
+			opts:       DetectOptions{Resolver: resolver},
+			wantErr:    true,
+			wantErrIs:  ErrPublicSuffix,
+		},
+		{
+			name:       "Public suffix as hostname",
+			identifier: "co.uk",
+			assetType:  Hostname,
+			opts:       DetectOptions{Resolver: resolver},
+			wantErr:    true,
+			wantErrIs:  ErrPublicSuffix,
+		},
+		{
+			name:       "Offline public suffix as hostname",
+			identifier: "co.uk",
+			assetType:  Hostname,
+			opts:       DetectOptions{Offline: true},
+			wantErr:    true,
+			wantErrIs:  ErrPublicSuffix,
+		},
+		{
+			name:       "Resolved web address",
+			identifier: "https://www.example.test/",
+			assetType:  WebAddress,
+			opts:       DetectOptions{Resolver: resolver},
+		},
+		{
+			name:       "Unresolved web address",
+			identifier: "https://intranet.example.test/",
+			assetType:  WebAddress,
+			opts:       DetectOptions{Resolver: resolver},
+			wantErr:    true,
+		},
+		{
+			name:       "Accepted unresolved web address",
+			identifier: "https://intranet.example.test/",
+			assetType:  WebAddress,
+			opts:       DetectOptions{Resolver: resolver, AcceptUnresolvedWebAddresses: true},
+		},
+		{
+			name:       "Offline web address",
+			identifier: "https://intranet.example.test/",
+			assetType:  WebAddress,
+			opts:       DetectOptions{Offline: true},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWithOptions(tt.identifier, tt.assetType, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("got error %v, want %v", err, tt.wantErrIs)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name       string
+		identifier string
+		assetType  AssetType
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "Hostname",
+			identifier: "WWW.München.de.",
+			assetType:  Hostname,
+			want:       "www.xn--mnchen-3ya.de",
+		},
+		{
+			name:       "Single IP CIDR",
+			identifier: "192.0.2.1/32",
+			assetType:  IP,
+			want:       "192.0.2.1",
+		},
+		{
+			name:       "IPv6",
+			identifier: "2001:DB8:0::1",
+			assetType:  IP,
+			want:       "2001:db8::1",
+		},
+		{
+			name:       "Web address",
+			identifier: "https://Example.com:443/a/../b/?utm_source=x#frag",
+			assetType:  WebAddress,
+			want:       "https://example.com/b",
+		},
+		{
+			name:       "Without normalization function",
+			identifier: "ghcr.io/puppeteer/puppeteer",
+			assetType:  DockerImage,
+			want:       "ghcr.io/puppeteer/puppeteer",
+		},
+		{
+			name:       "Unknown type",
+			identifier: "192.0.2.1",
+			assetType:  AssetType("Hostnme"),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.identifier, tt.assetType)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// HostStatus is the resolution status of the host of a web address.
+type HostStatus string
+
+// Host statuses.
+const (
+	// HostResolved means that the hostname resolves.
+	HostResolved HostStatus = "resolved"
+	// HostUnresolved means that the hostname does not resolve.
+	HostUnresolved HostStatus = "unresolved"
+	// HostIPLiteral means that the host is an IP address, so there is
+	// nothing to resolve.
+	HostIPLiteral HostStatus = "ip-literal"
+	// HostNotChecked means that the resolution was not checked
+	// because network access is disabled.
+	HostNotChecked HostStatus = "not-checked"
+)
+
+// WebAddressHostStatus returns the resolution status of the host of a
+// web address, as checked by [DetectAssetTypesWithOptions] with the
+// same options. It allows to know whether a web address detected with
+// [DetectOptions.AcceptUnresolvedWebAddresses] can be reached. In
+// offline mode, it returns HostNotChecked for hostnames. It returns
+// error if the target is not a web address.
+func WebAddressHostStatus(target string, opts DetectOptions) (HostStatus, error) {
+	if !IsWebAddress(target) {
+		return "", fmt.Errorf("not a web address: %v", target)
+	}
+	u, err := url.ParseRequestURI(target)
+	if err != nil {
+		return "", err
+	}
+
+	if _, ok := urlHostIP(u); ok {
+		return HostIPLiteral, nil
+	}
+	if opts.Offline {
+		return HostNotChecked, nil
+	}
+	if isHostname(u.Hostname(), opts.Resolver) {
+		return HostResolved, nil
+	}
+	return HostUnresolved, nil
+}
+
+// DefaultTrackingParams contains the query parameters removed by
+// [CanonicalizeWebAddress] when no tracking parameters are
+// configured. A trailing "*" matches any parameter with that prefix.
+var DefaultTrackingParams = []string{
+	"utm_*",
+	"gclid",
+	"dclid",
+	"fbclid",
+	"msclkid",
+	"yclid",
+	"mc_cid",
+	"mc_eid",
+	"_ga",
+	"_hsenc",
+	"_hsmi",
+}
+
+// CanonicalizeOptions configures [CanonicalizeWebAddress].
+type CanonicalizeOptions struct {
+	// TrackingParams contains the names of the query parameters to
+	// remove. A trailing "*" matches any parameter with that prefix.
+	// If nil, DefaultTrackingParams is used. Use an empty, non-nil
+	// slice to keep all the parameters.
+	TrackingParams []string
+}
+
+// CanonicalizeWebAddress returns the canonical form of a web address,
+// so different URLs pointing to the same resource map to the same
+// asset. For instance, "https://Example.com:443/a/../b/?utm_source=x#frag"
+// and "https://example.com/b" are both canonicalized to
+// "https://example.com/b".
+//
+// The canonical form is obtained by:
+//
+//   - removing the username and password, so secrets are not stored
+//     and URLs that only differ in their credentials are the same
+//   - lowercasing the scheme and the host, and converting
+//     internationalized hostnames to their A-label form
+//   - removing the default port of the scheme
+//   - resolving the dot-segments of the path, as described in RFC 3986
+//     section 5.2.4, and removing the trailing slash
+//   - decoding percent-encoded unreserved characters and uppercasing
+//     the remaining percent-encodings
+//   - removing the fragment and the tracking query parameters
+//   - sorting the query parameters by name and value
+//
+// It returns error if the target is not a web address.
+func CanonicalizeWebAddress(target string, opts CanonicalizeOptions) (string, error) {
+	if !IsWebAddress(target) {
+		return "", fmt.Errorf("not a web address: %v", target)
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return "", fmt.Errorf("parse web address: %w", err)
+	}
+
+	host, err := canonicalHost(u)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.ToLower(u.Scheme))
+	b.WriteString("://")
+	b.WriteString(host)
+
+	p := canonicalPath(u)
+	if len(p) > 1 {
+		p = strings.TrimSuffix(p, "/")
+	}
+	if p == "" {
+		p = "/"
+	}
+	b.WriteString(p)
+
+	trackingParams := opts.TrackingParams
+	if trackingParams == nil {
+		trackingParams = DefaultTrackingParams
+	}
+	if q := canonicalQuery(u.RawQuery, trackingParams); q != "" {
+		b.WriteString("?")
+		b.WriteString(q)
+	}
+	return b.String(), nil
+}
+
+// canonicalHost returns the canonical host of a web address,
+// including the port if it is not the default one of the scheme.
+func canonicalHost(u *url.URL) (string, error) {
+	var host string
+	if strings.HasPrefix(u.Host, "[") {
+		addr, err := netip.ParseAddr(u.Hostname())
+		if err != nil {
+			return "", fmt.Errorf("invalid IP: %w", err)
+		}
+		host = "[" + strings.Replace(addr.String(), "%", "%25", 1) + "]"
+	} else if addr, err := netip.ParseAddr(u.Hostname()); err == nil {
+		host = addr.String()
+	} else {
+		name, err := toASCIIHostname(u.Hostname())
+		if err != nil {
+			return "", fmt.Errorf("invalid hostname: %w", err)
+		}
+		host = name
+	}
+
+	port := u.Port()
+	if port == "" {
+		return host, nil
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil {
+		return "", errors.New("invalid port")
+	}
+	if (u.Scheme == "http" && n == 80) || (u.Scheme == "https" && n == 443) {
+		return host, nil
+	}
+	return host + ":" + strconv.Itoa(n), nil
+}
+
+// canonicalQuery returns the canonical form of a raw query, without
+// the tracking parameters and with the parameters sorted.
+func canonicalQuery(rawQuery string, trackingParams []string) string {
+	type param struct {
+		name, value, raw string
+	}
+
+	var params []param
+	for _, raw := range strings.Split(rawQuery, "&") {
+		if raw == "" {
+			continue
+		}
+		raw = normalizePercentEncoding(raw)
+		name, value, _ := strings.Cut(raw, "=")
+		if decoded, err := url.QueryUnescape(name); err == nil && isTrackingParam(decoded, trackingParams) {
+			continue
+		}
+		params = append(params, param{name: name, value: value, raw: raw})
+	}
+
+	sort.SliceStable(params, func(i, j int) bool {
+		if params[i].name != params[j].name {
+			return params[i].name < params[j].name
+		}
+		return params[i].value < params[j].value
+	})
+
+	raws := make([]string, len(params))
+	for i, p := range params {
+		raws[i] = p.raw
+	}
+	return strings.Join(raws, "&")
+}
+
+// isTrackingParam reports whether name matches any of the tracking
+// parameters.
+func isTrackingParam(name string, trackingParams []string) bool {
+	name = strings.ToLower(name)
+	for _, tp := range trackingParams {
+		tp = strings.ToLower(tp)
+		if prefix, ok := strings.CutSuffix(tp, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+			continue
+		}
+		if name == tp {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizePercentEncoding decodes the percent-encoded unreserved
+// characters of s, uppercases the hexadecimal digits of the remaining
+// percent-encodings and percent-encodes the characters that are
+// neither reserved nor unreserved.
+func normalizePercentEncoding(s string) string {
+	const hex = "0123456789ABCDEF"
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]) {
+			v, _ := strconv.ParseUint(s[i+1:i+3], 16, 8)
+			if isUnreserved(byte(v)) {
+				b.WriteByte(byte(v))
+			} else {
+				b.WriteByte('%')
+				b.WriteString(strings.ToUpper(s[i+1 : i+3]))
+			}
+			i += 2
+			continue
+		}
+		if isUnreserved(c) || strings.IndexByte(":/?#[]@!$&'()*+,;=", c) >= 0 {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hex[c>>4])
+		b.WriteByte(hex[c&0x0f])
+	}
+	return b.String()
+}
+
+// isUnreserved reports whether c is an unreserved character as
+// defined in RFC 3986 section 2.3.
+func isUnreserved(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+// isHex reports whether c is a hexadecimal digit.
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// canonicalPath returns the path of the URL with normalized
+// percent-encodings and without "." and ".." segments.
+func canonicalPath(u *url.URL) string {
+	return removeDotSegments(normalizePercentEncoding(u.EscapedPath()))
+}
+
+// removeDotSegments removes the "." and ".." segments of a path as
+// described in RFC 3986 section 5.2.4.
+func removeDotSegments(p string) string {
+	var out []string
+	for p != "" {
+		switch {
+		case strings.HasPrefix(p, "../"):
+			p = p[3:]
+		case strings.HasPrefix(p, "./"):
+			p = p[2:]
+		case strings.HasPrefix(p, "/./"):
+			p = p[2:]
+		case p == "/.":
+			p = "/"
+		case strings.HasPrefix(p, "/../"):
+			p = p[3:]
+			if len(out) > 0 {
+				out = out[:len(out)-1]
+			}
+		case p == "/..":
+			p = "/"
+			if len(out) > 0 {
+				out = out[:len(out)-1]
+			}
+		case p == "." || p == "..":
+			p = ""
+		default:
+			i := strings.IndexByte(p[1:], '/')
+			if i < 0 {
+				out = append(out, p)
+				p = ""
+			} else {
+				out = append(out, p[:i+1])
+				p = p[i+1:]
+			}
+		}
+	}
+	return strings.Join(out, "")
+}
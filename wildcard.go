@@ -0,0 +1,206 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/netip"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// wildcardProbes is the number of random labels resolved to
+	// detect a wildcard zone.
+	wildcardProbes = 3
+
+	// wildcardCacheTTL is the time the result of a wildcard detection
+	// is cached.
+	wildcardCacheTTL = 10 * time.Minute
+
+	// wildcardCacheSize is the maximum number of zones in the
+	// wildcard detection cache.
+	wildcardCacheSize = 1024
+)
+
+// wildcardCache caches the wildcard detection results per resolver
+// and zone.
+var wildcardCache = struct {
+	sync.Mutex
+	entries map[string]wildcardCacheEntry
+}{entries: make(map[string]wildcardCacheEntry)}
+
+type wildcardCacheEntry struct {
+	zone    WildcardZone
+	expires time.Time
+}
+
+// cachedWildcardZone returns the cached detection result for key. The
+// entry is evicted if it has expired.
+func cachedWildcardZone(key string) (WildcardZone, bool) {
+	wildcardCache.Lock()
+	defer wildcardCache.Unlock()
+
+	entry, ok := wildcardCache.entries[key]
+	if !ok {
+		return WildcardZone{}, false
+	}
+	if !time.Now().Before(entry.expires) {
+		delete(wildcardCache.entries, key)
+		return WildcardZone{}, false
+	}
+	return entry.zone, true
+}
+
+// cacheWildcardZone caches the detection result for key. If the cache
+// is full, the expired entries are evicted and, if it is still full,
+// the entry closest to expiring too.
+func cacheWildcardZone(key string, wz WildcardZone) {
+	wildcardCache.Lock()
+	defer wildcardCache.Unlock()
+
+	now := time.Now()
+	if _, ok := wildcardCache.entries[key]; !ok && len(wildcardCache.entries) >= wildcardCacheSize {
+		var (
+			oldest    string
+			oldestExp time.Time
+		)
+		for k, e := range wildcardCache.entries {
+			if !now.Before(e.expires) {
+				delete(wildcardCache.entries, k)
+				continue
+			}
+			if oldest == "" || e.expires.Before(oldestExp) {
+				oldest, oldestExp = k, e.expires
+			}
+		}
+		if len(wildcardCache.entries) >= wildcardCacheSize {
+			delete(wildcardCache.entries, oldest)
+		}
+	}
+	wildcardCache.entries[key] = wildcardCacheEntry{zone: wz, expires: now.Add(wildcardCacheTTL)}
+}
+
+// WildcardZone is the result of a wildcard DNS zone detection.
+type WildcardZone struct {
+	// Zone is the probed zone, in A-label form.
+	Zone string
+	// Wildcard reports whether the zone has a wildcard record, that
+	// is, whether random names under the zone resolve.
+	Wildcard bool
+	// Addresses contains the addresses returned for the random names,
+	// sorted.
+	Addresses []netip.Addr
+}
+
+// DetectWildcardZone reports whether a DNS zone has a wildcard record,
+// like "*.example.com", by resolving random labels under the zone
+// with [ResolveHostname]. Results are cached per zone for 10 minutes.
+func DetectWildcardZone(ctx context.Context, zone string) (WildcardZone, error) {
+	return detectWildcardZone(ctx, zone, "")
+}
+
+// detectWildcardZone is like [DetectWildcardZone] but queries the
+// provided DNS server. If resolver is empty, the servers in
+// /etc/resolv.conf are queried.
+func detectWildcardZone(ctx context.Context, zone, resolver string) (WildcardZone, error) {
+	name, err := toASCIILookupName(zone)
+	if err != nil {
+		return WildcardZone{}, err
+	}
+
+	key := resolver + "|" + name
+	if wz, ok := cachedWildcardZone(key); ok {
+		return wz, nil
+	}
+
+	wz := WildcardZone{Zone: name}
+	seen := make(map[netip.Addr]bool)
+	for i := 0; i < wildcardProbes; i++ {
+		label, err := randomLabel()
+		if err != nil {
+			return WildcardZone{}, err
+		}
+		res, err := resolveHostname(ctx, label+"."+name, resolver)
+		if errors.Is(err, ErrHostNotFound) {
+			continue
+		}
+		if err != nil {
+			return WildcardZone{}, err
+		}
+		wz.Wildcard = true
+		for _, a := range res.Addresses {
+			if !seen[a.IP] {
+				seen[a.IP] = true
+				wz.Addresses = append(wz.Addresses, a.IP)
+			}
+		}
+	}
+	sort.Slice(wz.Addresses, func(i, j int) bool {
+		return wz.Addresses[i].Less(wz.Addresses[j])
+	})
+
+	cacheWildcardZone(key, wz)
+	return wz, nil
+}
+
+// IsWildcardHostname reports whether a hostname only resolves because
+// its parent zone has a wildcard record, that is, whether all its
+// addresses are also returned for random names in the parent zone.
+// Such hostnames are usually typos and not real assets. It returns
+// false if the hostname does not resolve.
+func IsWildcardHostname(ctx context.Context, hostname string) (bool, error) {
+	return isWildcardHostname(ctx, hostname, "")
+}
+
+// isWildcardHostname is like [IsWildcardHostname] but queries the
+// provided DNS server. If resolver is empty, the servers in
+// /etc/resolv.conf are queried.
+func isWildcardHostname(ctx context.Context, hostname, resolver string) (bool, error) {
+	res, err := resolveHostname(ctx, hostname, resolver)
+	if errors.Is(err, ErrHostNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	_, parent, ok := strings.Cut(res.Name, ".")
+	if !ok {
+		return false, nil
+	}
+	wz, err := detectWildcardZone(ctx, parent, resolver)
+	if err != nil {
+		return false, err
+	}
+	if !wz.Wildcard {
+		return false, nil
+	}
+
+	for _, a := range res.Addresses {
+		i := sort.Search(len(wz.Addresses), func(i int) bool {
+			return !wz.Addresses[i].Less(a.IP)
+		})
+		if i == len(wz.Addresses) || wz.Addresses[i] != a.IP {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// randomLabel returns a random DNS label that is very unlikely to
+// exist.
+func randomLabel() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "vt-" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2026 Adevinta
+*/
+
+package types
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDetectWildcardZone(t *testing.T) {
+	startTestDNSServer(t, testZone{
+		"*.wild.example.test. 300 IN A 192.0.2.100",
+		"www.tame.example.test. 300 IN A 192.0.2.1",
+	})
+
+	tests := []struct {
+		name string
+		zone string
+		want WildcardZone
+	}{
+		{
+			name: "wildcard zone",
+			zone: "wild.example.test",
+			want: WildcardZone{
+				Zone:      "wild.example.test",
+				Wildcard:  true,
+				Addresses: []netip.Addr{netip.MustParseAddr("192.0.2.100")},
+			},
+		},
+		{
+			name: "zone without wildcard",
+			zone: "tame.example.test",
+			want: WildcardZone{Zone: "tame.example.test"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectWildcardZone(context.Background(), tt.zone)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got, cmp.Comparer(func(a, b netip.Addr) bool { return a == b })); diff != "" {
+				t.Errorf("zone mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestDetectWildcardZoneCache(t *testing.T) {
+	startTestDNSServer(t, testZone{
+		"*.cached.example.test. 300 IN A 192.0.2.100",
+	})
+	want, err := DetectWildcardZone(context.Background(), "cached.example.test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Replace the DNS server. The cached result must be returned.
+	startTestDNSServer(t, testZone{})
+	got, err := DetectWildcardZone(context.Background(), "cached.example.test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b netip.Addr) bool { return a == b })); diff != "" {
+		t.Errorf("zone mismatch (-want +got):\n%v", diff)
+	}
+}
+
+func TestWildcardCacheEviction(t *testing.T) {
+	wildcardCache.Lock()
+	prev := wildcardCache.entries
+	wildcardCache.entries = make(map[string]wildcardCacheEntry)
+	wildcardCache.Unlock()
+	t.Cleanup(func() {
+		wildcardCache.Lock()
+		wildcardCache.entries = prev
+		wildcardCache.Unlock()
+	})
+
+	// Expired entries are evicted when read.
+	wildcardCache.Lock()
+	wildcardCache.entries["|expired.example.test"] = wildcardCacheEntry{expires: time.Now().Add(-time.Second)}
+	wildcardCache.Unlock()
+	if _, ok := cachedWildcardZone("|expired.example.test"); ok {
+		t.Error("expired entry returned")
+	}
+	wildcardCache.Lock()
+	_, ok := wildcardCache.entries["|expired.example.test"]
+	wildcardCache.Unlock()
+	if ok {
+		t.Error("expired entry not evicted")
+	}
+
+	// The cache does not grow beyond its size.
+	for i := 0; i < wildcardCacheSize+10; i++ {
+		cacheWildcardZone(fmt.Sprintf("|zone%v.example.test", i), WildcardZone{})
+	}
+	wildcardCache.Lock()
+	n := len(wildcardCache.entries)
+	wildcardCache.Unlock()
+	if n != wildcardCacheSize {
+		t.Errorf("got %v cached entries, want %v", n, wildcardCacheSize)
+	}
+	last := fmt.Sprintf("|zone%v.example.test", wildcardCacheSize+9)
+	if _, ok := cachedWildcardZone(last); !ok {
+		t.Error("last cached entry evicted")
+	}
+}
+
+func TestIsWildcardHostname(t *testing.T) {
+	resolver := startTestDNSServer(t, testZone{
+		"*.wc.example.test. 300 IN A 192.0.2.100",
+		"www.wc.example.test. 300 IN A 192.0.2.1",
+		"api.tm.example.test. 300 IN A 192.0.2.2",
+	})
+
+	tests := []struct {
+		name     string
+		hostname string
+		want     bool
+	}{
+		{name: "typo in wildcard zone", hostname: "wwww.wc.example.test", want: true},
+		{name: "real hostname in wildcard zone", hostname: "www.wc.example.test", want: false},
+		{name: "hostname in zone without wildcard", hostname: "api.tm.example.test", want: false},
+		{name: "unknown hostname", hostname: "unknown.tm.example.test", want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IsWildcardHostname(context.Background(), tt.hostname)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var marked []string
+	opts := DetectOptions{
+		Resolver: resolver,
+		OnWildcardHostname: func(hostname string) {
+			marked = append(marked, hostname)
+		},
+	}
+	for _, identifier := range []string{"wwww.wc.example.test", "www.wc.example.test"} {
+		got, err := DetectAssetTypesWithOptions(identifier, opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if diff := cmp.Diff([]AssetType{Hostname}, got); diff != "" {
+			t.Errorf("asset types mismatch (-want +got):\n%v", diff)
+		}
+	}
+	if diff := cmp.Diff([]string{"wwww.wc.example.test"}, marked); diff != "" {
+		t.Errorf("marked hostnames mismatch (-want +got):\n%v", diff)
+	}
+}